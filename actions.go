@@ -0,0 +1,78 @@
+package samhook
+
+// 互動元件的類型
+const (
+	ActionButton = "button"
+	ActionSelect = "select"
+)
+
+// 按鈕樣式（Mattermost 另支援 good、warning、success 與十六進位顏色）
+const (
+	ActionStyleDefault = "default"
+	ActionStylePrimary = "primary"
+	ActionStyleDanger  = "danger"
+)
+
+// 選單的資料來源
+const (
+	DataSourceUsers    = "users"
+	DataSourceChannels = "channels"
+)
+
+// Action attachment 的互動元件（Slack 舊版 attachment 與 Mattermost 互動訊息）
+//
+// Slack 以 Name 識別操作、Text 作為顯示文字，並將互動發送到 app 的
+// Request URL；Mattermost 以 ID 識別操作、Name 作為顯示文字，並將互動
+// 發送到 Integration.URL。
+type Action struct {
+	ID    string `json:"id,omitempty"`
+	Name  string `json:"name,omitempty"`
+	Text  string `json:"text,omitempty"`
+	Type  string `json:"type"`
+	Value string `json:"value,omitempty"`
+	Style string `json:"style,omitempty"`
+
+	// URL 連結按鈕開啟的網址（僅 Slack）
+	URL string `json:"url,omitempty"`
+
+	// Confirm 執行前顯示的確認對話框（僅 Slack）
+	Confirm *Confirm `json:"confirm,omitempty"`
+
+	// Options 選單的選項，DataSource 為 users 或 channels 時由平台提供
+	Options       []Option `json:"options,omitempty"`
+	DataSource    string   `json:"data_source,omitempty"`
+	DefaultOption string   `json:"default_option,omitempty"`
+
+	// Integration 互動時 Mattermost 發送請求的目標（僅 Mattermost）
+	Integration *Integration `json:"integration,omitempty"`
+}
+
+// Confirm 確認對話框
+type Confirm struct {
+	Title       string `json:"title,omitempty"`
+	Text        string `json:"text"`
+	OkText      string `json:"ok_text,omitempty"`
+	DismissText string `json:"dismiss_text,omitempty"`
+}
+
+// Option 選單的選項
+type Option struct {
+	Text  string `json:"text"`
+	Value string `json:"value"`
+}
+
+// Integration Mattermost 互動時發送的請求，Context 會原樣帶入請求內容
+type Integration struct {
+	URL     string         `json:"url"`
+	Context map[string]any `json:"context,omitempty"`
+}
+
+// NewIntegration 創建 Integration，context 中加入 action_id 供 inbound 套件分派
+func NewIntegration(url, actionID string, context map[string]any) *Integration {
+	ctx := make(map[string]any, len(context)+1)
+	for k, v := range context {
+		ctx[k] = v
+	}
+	ctx["action_id"] = actionID
+	return &Integration{URL: url, Context: ctx}
+}
@@ -0,0 +1,61 @@
+package samhook
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bytedance/sonic"
+)
+
+func TestAttachment_Actions(t *testing.T) {
+	attachment := Attachment{
+		Fallback:       "Approve deploy?",
+		CallbackID:     "deploy_approval",
+		AttachmentType: "default",
+		MrkdwnIn:       []string{"text"},
+		TS:             1700000000,
+		Actions: []Action{
+			{
+				ID:          "approve",
+				Name:        "Approve",
+				Type:        ActionButton,
+				Style:       ActionStylePrimary,
+				Confirm:     &Confirm{Title: "Deploy", Text: "Deploy to prod?", OkText: "Yes", DismissText: "No"},
+				Integration: NewIntegration("https://bot.example.com/actions", "approve", map[string]any{"deploy": "42"}),
+			},
+			{
+				Name:    "env",
+				Type:    ActionSelect,
+				Options: []Option{{Text: "Production", Value: "prod"}},
+			},
+		},
+	}
+
+	data, err := sonic.Marshal(attachment)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	for _, want := range []string{
+		`"callback_id":"deploy_approval"`,
+		`"attachment_type":"default"`,
+		`"mrkdwn_in":["text"]`,
+		`"ts":1700000000`,
+		`"style":"primary"`,
+		`"ok_text":"Yes"`,
+		`"options":[{"text":"Production","value":"prod"}]`,
+		`"url":"https://bot.example.com/actions"`,
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("JSON missing %s: %s", want, data)
+		}
+	}
+
+	var decoded Attachment
+	if err := sonic.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	ctx := decoded.Actions[0].Integration.Context
+	if ctx["action_id"] != "approve" || ctx["deploy"] != "42" {
+		t.Errorf("unexpected integration context: %v", ctx)
+	}
+}
@@ -0,0 +1,314 @@
+// Package alertmanager 接收 Prometheus Alertmanager webhook 並轉發為 samhook 訊息
+package alertmanager
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/circleyu/samhook"
+)
+
+// 告警狀態
+const (
+	StatusFiring   = "firing"
+	StatusResolved = "resolved"
+)
+
+// 預設範本
+const (
+	DefaultTitleTemplate = `[{{ .Status | upper }}{{ if .Firing }}:{{ len .Firing }}{{ end }}] {{ .GroupLabels | labels }}`
+	DefaultAlertTemplate = `{{ with index .Annotations "summary" }}{{ . }}{{ end }}` +
+		`{{ with index .Annotations "description" }}{{ "\n" }}{{ . }}{{ end }}`
+)
+
+// maxBodySize 請求體大小上限
+const maxBodySize = 4 << 20
+
+// Data Alertmanager webhook（version 4）的請求內容
+type Data struct {
+	Version           string            `json:"version"`
+	GroupKey          string            `json:"groupKey"`
+	TruncatedAlerts   int               `json:"truncatedAlerts"`
+	Status            string            `json:"status"`
+	Receiver          string            `json:"receiver"`
+	GroupLabels       map[string]string `json:"groupLabels"`
+	CommonLabels      map[string]string `json:"commonLabels"`
+	CommonAnnotations map[string]string `json:"commonAnnotations"`
+	ExternalURL       string            `json:"externalURL"`
+	Alerts            []Alert           `json:"alerts"`
+}
+
+// Alert 單一告警
+type Alert struct {
+	Status       string            `json:"status"`
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations"`
+	StartsAt     time.Time         `json:"startsAt"`
+	EndsAt       time.Time         `json:"endsAt"`
+	GeneratorURL string            `json:"generatorURL"`
+	Fingerprint  string            `json:"fingerprint"`
+}
+
+// Firing 返回觸發中的告警
+func (d *Data) Firing() []Alert {
+	return d.filter(StatusFiring)
+}
+
+// Resolved 返回已解除的告警
+func (d *Data) Resolved() []Alert {
+	return d.filter(StatusResolved)
+}
+
+// filter 返回指定狀態的告警
+func (d *Data) filter(status string) []Alert {
+	var alerts []Alert
+	for _, a := range d.Alerts {
+		if a.Status == status {
+			alerts = append(alerts, a)
+		}
+	}
+	return alerts
+}
+
+// Options 接收器選項
+type Options struct {
+	// Username、IconEmoji、Channel 訊息預設值
+	Username  string
+	IconEmoji string
+	Channel   string
+
+	// TitleTemplate 訊息標題範本（以 *Data 執行），為空時使用 DefaultTitleTemplate
+	TitleTemplate string
+
+	// AlertTemplate 每個告警的內容範本（以 Alert 執行），為空時使用 DefaultAlertTemplate
+	AlertTemplate string
+
+	// Render 自訂轉換函數，設置後忽略範本
+	Render func(d *Data) (samhook.Message, error)
+
+	// Provider 目標平台，決定訊息限制與編碼格式，為 nil 時依 URL 偵測
+	Provider samhook.Provider
+
+	// Retry 重試選項，為 nil 時使用 samhook.DefaultRetryOptions
+	Retry *samhook.RetryOptions
+
+	// ClientOptions HTTP 客戶端選項
+	ClientOptions []samhook.ClientOption
+
+	// ErrorLog 記錄轉發失敗的詳細錯誤（含 webhook URL），為 nil 時使用 log 套件的預設 logger
+	ErrorLog *log.Logger
+}
+
+// Handler 接收 Alertmanager webhook 並轉發到目標 webhook 的 http.Handler
+type Handler struct {
+	opts      Options
+	titleTmpl *template.Template
+	alertTmpl *template.Template
+	dest      samhook.Destination
+}
+
+// templateFuncs 範本可用的函數
+var templateFuncs = template.FuncMap{
+	"upper":  strings.ToUpper,
+	"lower":  strings.ToLower,
+	"join":   strings.Join,
+	"labels": formatLabels,
+}
+
+// NewHandler 創建轉發到 webhookURL 的接收器，範本語法錯誤時返回錯誤
+func NewHandler(webhookURL string, opts *Options) (*Handler, error) {
+	h := &Handler{}
+	if opts != nil {
+		h.opts = *opts
+	}
+
+	titleText := h.opts.TitleTemplate
+	if titleText == "" {
+		titleText = DefaultTitleTemplate
+	}
+	alertText := h.opts.AlertTemplate
+	if alertText == "" {
+		alertText = DefaultAlertTemplate
+	}
+
+	var err error
+	if h.titleTmpl, err = template.New("title").Funcs(templateFuncs).Parse(titleText); err != nil {
+		return nil, fmt.Errorf("invalid title template: %w", err)
+	}
+	if h.alertTmpl, err = template.New("alert").Funcs(templateFuncs).Parse(alertText); err != nil {
+		return nil, fmt.Errorf("invalid alert template: %w", err)
+	}
+
+	retry := samhook.DefaultRetryOptions
+	if h.opts.Retry != nil {
+		retry = *h.opts.Retry
+	}
+	h.dest = samhook.Destination{
+		URL:           webhookURL,
+		Provider:      h.opts.Provider,
+		LimitStrategy: samhook.LimitSplit,
+		Retry:         &retry,
+		ClientOptions: h.opts.ClientOptions,
+	}
+
+	return h, nil
+}
+
+// ServeHTTP 解析請求、轉換並轉發訊息
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+	var data Data
+	if err := sonic.Unmarshal(body, &data); err != nil {
+		http.Error(w, fmt.Sprintf("invalid payload: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	msg, err := h.Render(&data)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("render failed: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	// 使用請求的 context，Alertmanager 逾時後停止重試，避免與重送的請求重複發送
+	if err := h.dest.Send(r.Context(), msg); err != nil {
+		// 錯誤訊息含有 webhook URL（密鑰），只記錄在本地，回應使用通用的內容
+		h.logf("alertmanager: forward failed: %v", err)
+		var webhookErr *samhook.WebhookError
+		if errors.As(err, &webhookErr) && webhookErr.IsValidationError() {
+			// 無法成功的訊息返回 4xx，Alertmanager 不會重送
+			http.Error(w, "message rejected", http.StatusUnprocessableEntity)
+			return
+		}
+		// 返回 5xx 讓 Alertmanager 稍後重送
+		http.Error(w, "forward failed", http.StatusBadGateway)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// logf 記錄錯誤到 ErrorLog
+func (h *Handler) logf(format string, args ...any) {
+	if h.opts.ErrorLog != nil {
+		h.opts.ErrorLog.Printf(format, args...)
+		return
+	}
+	log.Printf(format, args...)
+}
+
+// Render 將 Alertmanager 資料轉換為訊息
+func (h *Handler) Render(d *Data) (samhook.Message, error) {
+	if h.opts.Render != nil {
+		return h.opts.Render(d)
+	}
+
+	title, err := execute(h.titleTmpl, d)
+	if err != nil {
+		return samhook.Message{}, err
+	}
+
+	msg := samhook.Message{
+		Username:  h.opts.Username,
+		IconEmoji: h.opts.IconEmoji,
+		Channel:   h.opts.Channel,
+		Text:      title,
+	}
+	for _, a := range d.Alerts {
+		text, err := execute(h.alertTmpl, a)
+		if err != nil {
+			return samhook.Message{}, err
+		}
+		msg.AddAttachment(alertAttachment(a, text, d.GroupLabels))
+	}
+	if d.TruncatedAlerts > 0 {
+		msg.Text += fmt.Sprintf("\n(%d alerts truncated by Alertmanager)", d.TruncatedAlerts)
+	}
+	return msg, nil
+}
+
+// alertAttachment 將單一告警轉換為 attachment，分組標籤不重複列出
+func alertAttachment(a Alert, text string, groupLabels map[string]string) samhook.Attachment {
+	color := samhook.Danger
+	if a.Status == StatusResolved {
+		color = samhook.Good
+	}
+
+	title := a.Labels["alertname"]
+	if title == "" {
+		title = "alert"
+	}
+
+	var fields []samhook.Field
+	for _, k := range sortedKeys(a.Labels) {
+		if k == "alertname" {
+			continue
+		}
+		if _, ok := groupLabels[k]; ok {
+			continue
+		}
+		fields = append(fields, samhook.Field{Title: k, Value: a.Labels[k], Short: true})
+	}
+
+	footer := "started " + a.StartsAt.Format(time.RFC3339)
+	if a.Status == StatusResolved && !a.EndsAt.IsZero() {
+		footer += ", resolved " + a.EndsAt.Format(time.RFC3339)
+	}
+
+	return samhook.Attachment{
+		Fallback:  fmt.Sprintf("[%s] %s", strings.ToUpper(a.Status), title),
+		Color:     color,
+		Title:     fmt.Sprintf("[%s] %s", strings.ToUpper(a.Status), title),
+		TitleLink: a.GeneratorURL,
+		Text:      text,
+		Fields:    fields,
+		Footer:    footer,
+	}
+}
+
+// execute 執行範本並返回結果
+func execute(t *template.Template, data any) (string, error) {
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("template %s: %w", t.Name(), err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// formatLabels 將標籤依鍵排序後格式化為 k=v 列表
+func formatLabels(labels map[string]string) string {
+	keys := sortedKeys(labels)
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = k + "=" + labels[k]
+	}
+	return strings.Join(pairs, " ")
+}
+
+// sortedKeys 返回排序後的鍵
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,242 @@
+package alertmanager
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/circleyu/samhook"
+)
+
+const testPayload = `{
+  "version": "4",
+  "groupKey": "{}:{alertname=\"HighLatency\"}",
+  "truncatedAlerts": 0,
+  "status": "firing",
+  "receiver": "slack",
+  "groupLabels": {"alertname": "HighLatency"},
+  "commonLabels": {"alertname": "HighLatency", "severity": "critical"},
+  "commonAnnotations": {"summary": "Latency is high"},
+  "externalURL": "http://alertmanager:9093",
+  "alerts": [
+    {
+      "status": "firing",
+      "labels": {"alertname": "HighLatency", "instance": "web-1", "severity": "critical"},
+      "annotations": {"summary": "p99 > 1s", "description": "web-1 is slow"},
+      "startsAt": "2024-01-02T03:04:05Z",
+      "endsAt": "0001-01-01T00:00:00Z",
+      "generatorURL": "http://prometheus:9090/graph",
+      "fingerprint": "abc"
+    },
+    {
+      "status": "resolved",
+      "labels": {"alertname": "HighLatency", "instance": "web-2", "severity": "critical"},
+      "annotations": {"summary": "p99 > 1s"},
+      "startsAt": "2024-01-02T03:00:00Z",
+      "endsAt": "2024-01-02T03:10:00Z",
+      "fingerprint": "def"
+    }
+  ]
+}`
+
+// targetServer 創建接收轉發訊息的 mock webhook
+func targetServer(t *testing.T, status int) (*httptest.Server, func() []samhook.Message) {
+	var mu sync.Mutex
+	var received []samhook.Message
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var msg samhook.Message
+		if err := sonic.Unmarshal(body, &msg); err != nil {
+			t.Errorf("invalid JSON: %v", err)
+		}
+		mu.Lock()
+		received = append(received, msg)
+		mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []samhook.Message {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]samhook.Message(nil), received...)
+	}
+}
+
+func TestHandler_Forward(t *testing.T) {
+	target, received := targetServer(t, http.StatusOK)
+	handler, err := NewHandler(target.URL, &Options{Username: "alertmanager"})
+	if err != nil {
+		t.Fatalf("NewHandler() error = %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(testPayload)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	msgs := received()
+	if len(msgs) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(msgs))
+	}
+	msg := msgs[0]
+	if msg.Username != "alertmanager" {
+		t.Errorf("unexpected username %q", msg.Username)
+	}
+	if msg.Text != "[FIRING:1] alertname=HighLatency" {
+		t.Errorf("unexpected title %q", msg.Text)
+	}
+	if len(msg.Attachments) != 2 {
+		t.Fatalf("expected 2 attachments, got %d", len(msg.Attachments))
+	}
+
+	firing, resolved := msg.Attachments[0], msg.Attachments[1]
+	if firing.Color != samhook.Danger || resolved.Color != samhook.Good {
+		t.Errorf("unexpected colors: %s, %s", firing.Color, resolved.Color)
+	}
+	if firing.Text != "p99 > 1s\nweb-1 is slow" {
+		t.Errorf("unexpected alert text %q", firing.Text)
+	}
+	if firing.TitleLink != "http://prometheus:9090/graph" {
+		t.Errorf("unexpected title link %q", firing.TitleLink)
+	}
+	// alertname 為分組標籤，不應重複列出
+	if len(firing.Fields) != 2 || firing.Fields[0].Title != "instance" || firing.Fields[1].Title != "severity" {
+		t.Errorf("unexpected fields: %+v", firing.Fields)
+	}
+}
+
+func TestHandler_CustomTemplates(t *testing.T) {
+	target, received := targetServer(t, http.StatusOK)
+	handler, err := NewHandler(target.URL, &Options{
+		TitleTemplate: `{{ .Receiver }}: {{ index .CommonAnnotations "summary" }}`,
+		AlertTemplate: `{{ index .Labels "instance" }}`,
+	})
+	if err != nil {
+		t.Fatalf("NewHandler() error = %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(testPayload)))
+
+	msgs := received()
+	if len(msgs) != 1 || msgs[0].Text != "slack: Latency is high" || msgs[0].Attachments[1].Text != "web-2" {
+		t.Errorf("unexpected message: %+v", msgs)
+	}
+}
+
+func TestHandler_Errors(t *testing.T) {
+	if _, err := NewHandler("http://example.com", &Options{TitleTemplate: "{{ .Broken"}); err == nil {
+		t.Error("expected template parse error")
+	}
+
+	target, _ := targetServer(t, http.StatusOK)
+	handler, _ := NewHandler(target.URL, nil)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("not json")))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", rec.Code)
+	}
+}
+
+func TestHandler_UpstreamFailure(t *testing.T) {
+	target, received := targetServer(t, http.StatusServiceUnavailable)
+	retry := samhook.RetryOptions{MaxRetries: 2, Interval: time.Millisecond}
+	var logs bytes.Buffer
+	webhookURL := target.URL + "/services/T000/B000/secret-token"
+	handler, _ := NewHandler(webhookURL, &Options{Retry: &retry, ErrorLog: log.New(&logs, "", 0)})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(testPayload)))
+	if rec.Code != http.StatusBadGateway {
+		t.Errorf("expected 502, got %d", rec.Code)
+	}
+	if n := len(received()); n != 3 {
+		t.Errorf("expected 3 attempts, got %d", n)
+	}
+	// webhook URL 只出現在本地日誌，不返回給 Alertmanager
+	if strings.Contains(rec.Body.String(), "secret-token") {
+		t.Errorf("response leaks webhook URL: %s", rec.Body)
+	}
+	if !strings.Contains(logs.String(), "secret-token") {
+		t.Errorf("expected detailed error in log, got %q", logs.String())
+	}
+}
+
+func TestHandler_ValidationFailure(t *testing.T) {
+	target, received := targetServer(t, http.StatusOK)
+	var logs bytes.Buffer
+	// 缺少 chat_id 的 Telegram 訊息無法發送，重送也不會成功
+	handler, _ := NewHandler(target.URL, &Options{Provider: samhook.TelegramProvider{}, ErrorLog: log.New(&logs, "", 0)})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(testPayload)))
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected 422, got %d: %s", rec.Code, rec.Body)
+	}
+	if n := len(received()); n != 0 {
+		t.Errorf("expected no attempts, got %d", n)
+	}
+	if logs.Len() == 0 {
+		t.Error("expected error to be logged")
+	}
+}
+
+func TestHandler_RequestContext(t *testing.T) {
+	target, received := targetServer(t, http.StatusServiceUnavailable)
+	retry := samhook.RetryOptions{MaxRetries: 3, Interval: time.Hour}
+	handler, _ := NewHandler(target.URL, &Options{Retry: &retry})
+
+	// Alertmanager 逾時後不再重試
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(testPayload)).WithContext(ctx)
+
+	start := time.Now()
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("handler ignored request context, took %v", elapsed)
+	}
+	if rec.Code != http.StatusBadGateway {
+		t.Errorf("expected 502, got %d", rec.Code)
+	}
+	if n := len(received()); n != 1 {
+		t.Errorf("expected 1 attempt, got %d", n)
+	}
+}
+
+func TestHandler_ProviderEncoding(t *testing.T) {
+	var body []byte
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(target.Close)
+
+	// 代理後的 URL 無法辨識平台，以 Provider 決定編碼與成功的狀態碼
+	handler, _ := NewHandler(target.URL+"/proxy/alerts", &Options{Provider: samhook.DiscordProvider{}})
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(testPayload)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+	if !strings.Contains(string(body), `"embeds"`) {
+		t.Errorf("expected Discord payload, got %s", body)
+	}
+}
@@ -0,0 +1,259 @@
+package samhook
+
+import (
+	"time"
+)
+
+// MessageBuilder 以鏈式呼叫建立 Message
+//
+//	msg, err := NewMessage().
+//		Text("deploy failed").
+//		Attachment(func(a *AttachmentBuilder) {
+//			a.Color(Danger).Field("Host", host, true)
+//		}).
+//		Build()
+type MessageBuilder struct {
+	msg      Message
+	provider Provider
+}
+
+// NewMessage 創建 MessageBuilder
+func NewMessage() *MessageBuilder {
+	return &MessageBuilder{}
+}
+
+// For 設置 Build 驗證與 Payload 編碼使用的平台
+func (b *MessageBuilder) For(provider Provider) *MessageBuilder {
+	b.provider = provider
+	return b
+}
+
+// Text 設置訊息文字
+func (b *MessageBuilder) Text(text string) *MessageBuilder {
+	b.msg.Text = text
+	return b
+}
+
+// Username 設置顯示名稱
+func (b *MessageBuilder) Username(username string) *MessageBuilder {
+	b.msg.Username = username
+	return b
+}
+
+// IconURL 設置頭像圖片
+func (b *MessageBuilder) IconURL(url string) *MessageBuilder {
+	b.msg.IconURL = url
+	return b
+}
+
+// IconEmoji 設置頭像 emoji
+func (b *MessageBuilder) IconEmoji(emoji string) *MessageBuilder {
+	b.msg.IconEmoji = emoji
+	return b
+}
+
+// Channel 設置頻道
+func (b *MessageBuilder) Channel(channel string) *MessageBuilder {
+	b.msg.Channel = channel
+	return b
+}
+
+// Thread 回覆討論串，broadcast 為 true 時同時發送到頻道
+func (b *MessageBuilder) Thread(ts string, broadcast bool) *MessageBuilder {
+	b.msg.ThreadTS = ts
+	b.msg.ReplyBroadcast = broadcast
+	return b
+}
+
+// Attachment 以 AttachmentBuilder 建立並加入一個 attachment
+func (b *MessageBuilder) Attachment(build func(a *AttachmentBuilder)) *MessageBuilder {
+	a := &AttachmentBuilder{}
+	build(a)
+	b.msg.Attachments = append(b.msg.Attachments, a.Build())
+	return b
+}
+
+// AddAttachment 加入已建立的 attachment
+func (b *MessageBuilder) AddAttachment(attachment Attachment) *MessageBuilder {
+	b.msg.Attachments = append(b.msg.Attachments, attachment)
+	return b
+}
+
+// Block 加入 Block Kit 區塊
+func (b *MessageBuilder) Block(blocks ...Block) *MessageBuilder {
+	b.msg.Blocks = append(b.msg.Blocks, blocks...)
+	return b
+}
+
+// Header 加入標題區塊
+func (b *MessageBuilder) Header(text string) *MessageBuilder {
+	return b.Block(Block{"type": "header", "text": plainText(text)})
+}
+
+// Section 加入 mrkdwn 文字區塊
+func (b *MessageBuilder) Section(text string) *MessageBuilder {
+	return b.Block(Block{"type": "section", "text": mrkdwnText(text)})
+}
+
+// Divider 加入分隔線區塊
+func (b *MessageBuilder) Divider() *MessageBuilder {
+	return b.Block(Block{"type": "divider"})
+}
+
+// Context 加入 mrkdwn 文字組成的註解區塊
+func (b *MessageBuilder) Context(texts ...string) *MessageBuilder {
+	elements := make([]any, len(texts))
+	for i, text := range texts {
+		elements[i] = mrkdwnText(text)
+	}
+	return b.Block(Block{"type": "context", "elements": elements})
+}
+
+// Message 返回目前的訊息（不驗證）
+func (b *MessageBuilder) Message() Message {
+	return b.msg
+}
+
+// Build 驗證並返回訊息，驗證失敗時返回 WebhookError（包含 *InvalidMessageError）
+func (b *MessageBuilder) Build() (Message, error) {
+	if err := validateMessage(b.msg, b.provider); err != nil {
+		return Message{}, err
+	}
+	return b.msg, nil
+}
+
+// Payload 驗證並編碼為平台的請求內容，未設置平台時依 webhookURL 偵測
+func (b *MessageBuilder) Payload(webhookURL string) ([]byte, error) {
+	provider := resolveProvider(webhookURL, b.provider)
+	if err := validateMessage(b.msg, provider); err != nil {
+		return nil, err
+	}
+	return encodeMessage(webhookURL, provider, b.msg)
+}
+
+// AttachmentBuilder 以鏈式呼叫建立 Attachment
+type AttachmentBuilder struct {
+	a Attachment
+}
+
+// NewAttachment 創建 AttachmentBuilder
+func NewAttachment() *AttachmentBuilder {
+	return &AttachmentBuilder{}
+}
+
+// Fallback 設置純文字摘要（未設置時 Build 使用標題或內容）
+func (b *AttachmentBuilder) Fallback(text string) *AttachmentBuilder {
+	b.a.Fallback = text
+	return b
+}
+
+// Color 設置顏色（Good、Warning、Danger 或十六進位），在 Severity 之後呼叫時覆蓋其顏色
+func (b *AttachmentBuilder) Color(color string) *AttachmentBuilder {
+	b.a.Color = color
+	return b
+}
+
+// Severity 依嚴重程度設置顏色與各平台的樣式
+func (b *AttachmentBuilder) Severity(sev Severity) *AttachmentBuilder {
+	b.a.Color = sev.Color()
+	b.a.Severity = sev
+	return b
+}
+
+// Pretext 設置 attachment 上方的文字
+func (b *AttachmentBuilder) Pretext(text string) *AttachmentBuilder {
+	b.a.Pretext = text
+	return b
+}
+
+// Author 設置作者名稱、連結與圖示
+func (b *AttachmentBuilder) Author(name, link, icon string) *AttachmentBuilder {
+	b.a.AuthorName = name
+	b.a.AuthorLink = link
+	b.a.AuthorIcon = icon
+	return b
+}
+
+// Title 設置標題與連結
+func (b *AttachmentBuilder) Title(title, link string) *AttachmentBuilder {
+	b.a.Title = title
+	b.a.TitleLink = link
+	return b
+}
+
+// Text 設置內容
+func (b *AttachmentBuilder) Text(text string) *AttachmentBuilder {
+	b.a.Text = text
+	return b
+}
+
+// Field 加入欄位，short 為 true 時與其他短欄位並排
+func (b *AttachmentBuilder) Field(title, value string, short bool) *AttachmentBuilder {
+	b.a.Fields = append(b.a.Fields, Field{Title: title, Value: value, Short: short})
+	return b
+}
+
+// Image 設置圖片
+func (b *AttachmentBuilder) Image(url string) *AttachmentBuilder {
+	b.a.ImageURL = url
+	return b
+}
+
+// Thumb 設置縮圖
+func (b *AttachmentBuilder) Thumb(url string) *AttachmentBuilder {
+	b.a.ThumbURL = url
+	return b
+}
+
+// Footer 設置頁尾文字與圖示
+func (b *AttachmentBuilder) Footer(text, icon string) *AttachmentBuilder {
+	b.a.Footer = text
+	b.a.FooterIcon = icon
+	return b
+}
+
+// Timestamp 設置顯示在頁尾旁的時間
+func (b *AttachmentBuilder) Timestamp(t time.Time) *AttachmentBuilder {
+	b.a.TS = t.Unix()
+	return b
+}
+
+// MarkdownIn 設置以 mrkdwn 解析的欄位
+func (b *AttachmentBuilder) MarkdownIn(fields ...string) *AttachmentBuilder {
+	b.a.MrkdwnIn = fields
+	return b
+}
+
+// CallbackID 設置互動時返回的識別碼
+func (b *AttachmentBuilder) CallbackID(id string) *AttachmentBuilder {
+	b.a.CallbackID = id
+	return b
+}
+
+// Action 加入按鈕或選單
+func (b *AttachmentBuilder) Action(actions ...Action) *AttachmentBuilder {
+	b.a.Actions = append(b.a.Actions, actions...)
+	return b
+}
+
+// Build 返回 attachment，未設置 Fallback 時使用標題或內容
+func (b *AttachmentBuilder) Build() Attachment {
+	a := b.a
+	if a.Fallback == "" {
+		a.Fallback = firstNonEmpty(a.Title, a.Text, a.Pretext)
+	}
+	if len(a.Actions) > 0 && a.AttachmentType == "" {
+		a.AttachmentType = "default"
+	}
+	return a
+}
+
+// plainText Block Kit 的 plain_text 物件
+func plainText(text string) map[string]any {
+	return map[string]any{"type": "plain_text", "text": text}
+}
+
+// mrkdwnText Block Kit 的 mrkdwn 物件
+func mrkdwnText(text string) map[string]any {
+	return map[string]any{"type": "mrkdwn", "text": text}
+}
@@ -0,0 +1,107 @@
+package samhook
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bytedance/sonic"
+)
+
+func TestMessageBuilder_Build(t *testing.T) {
+	ts := time.Unix(1700000000, 0)
+	msg, err := NewMessage().
+		Text("deploy failed").
+		Username("ci").
+		IconEmoji(":rotating_light:").
+		Channel("#ops").
+		Attachment(func(a *AttachmentBuilder) {
+			a.Color(Danger).
+				Title("api", "https://ci.example.com/42").
+				Field("Host", "web-1", true).
+				Field("Region", "us-east-1", true).
+				Footer("ci", "").
+				Timestamp(ts)
+		}).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	want := Message{
+		Text:      "deploy failed",
+		Username:  "ci",
+		IconEmoji: ":rotating_light:",
+		Channel:   "#ops",
+		Attachments: []Attachment{{
+			Fallback:  "api",
+			Color:     Danger,
+			Title:     "api",
+			TitleLink: "https://ci.example.com/42",
+			Fields: []Field{
+				{Title: "Host", Value: "web-1", Short: true},
+				{Title: "Region", Value: "us-east-1", Short: true},
+			},
+			Footer: "ci",
+			TS:     1700000000,
+		}},
+	}
+	got, _ := sonic.Marshal(msg)
+	expected, _ := sonic.Marshal(want)
+	if string(got) != string(expected) {
+		t.Errorf("Build() =\n%s\nwant\n%s", got, expected)
+	}
+}
+
+func TestMessageBuilder_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *MessageBuilder
+		wantErr bool
+	}{
+		{name: "空訊息", builder: NewMessage(), wantErr: true},
+		{name: "無效的顏色", builder: NewMessage().Attachment(func(a *AttachmentBuilder) { a.Text("x").Color("red") }), wantErr: true},
+		{name: "超出平台限制", builder: NewMessage().For(DiscordProvider{}).Text(strings.Repeat("a", 2001)), wantErr: true},
+		{name: "只有區塊", builder: NewMessage().Header("Deploy").Divider().Section("*done*").Context("by ci"), wantErr: false},
+		{name: "只有區塊（不支援區塊的平台）", builder: NewMessage().For(DiscordProvider{}).Divider(), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.builder.Build()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Build() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var invalid *InvalidMessageError
+			if err != nil && !errors.As(err, &invalid) {
+				t.Errorf("expected *InvalidMessageError, got %v", err)
+			}
+		})
+	}
+}
+
+func TestMessageBuilder_Payload(t *testing.T) {
+	b := NewMessage().Text("hello").Attachment(func(a *AttachmentBuilder) {
+		a.Title("api", "").Color(Good)
+	})
+
+	// 依 URL 偵測平台，Discord 編碼為 embed
+	data, err := b.Payload("https://discord.com/api/webhooks/1/token")
+	if err != nil {
+		t.Fatalf("Payload() error = %v", err)
+	}
+	var discord discordPayload
+	if err := sonic.Unmarshal(data, &discord); err != nil || len(discord.Embeds) != 1 || discord.Content != "hello" {
+		t.Errorf("unexpected Discord payload: %s", data)
+	}
+
+	// 明確指定的平台優先
+	data, err = b.For(SlackProvider{}).Payload("https://discord.com/api/webhooks/1/token")
+	if err != nil {
+		t.Fatalf("Payload() error = %v", err)
+	}
+	var slack Message
+	if err := sonic.Unmarshal(data, &slack); err != nil || len(slack.Attachments) != 1 {
+		t.Errorf("unexpected Slack payload: %s", data)
+	}
+}
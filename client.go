@@ -1,12 +1,9 @@
 package samhook
 
 import (
-	"bytes"
 	"context"
 	"net/http"
 	"time"
-
-	"github.com/bytedance/sonic"
 )
 
 // ClientOption 客戶端選項
@@ -33,32 +30,23 @@ const DefaultTimeout = 10 * time.Second
 
 // SendWithOptions 使用選項發送訊息
 func SendWithOptions(url string, msg Message, opts ...ClientOption) error {
-	client := &http.Client{
-		Timeout: DefaultTimeout,
-	}
-	// 應用選項
-	for _, opt := range opts {
-		opt(client)
-	}
-
-	payloadBytes, err := sonic.Marshal(msg)
-	if err != nil {
-		return NewSerializationError(err)
-	}
-	body := bytes.NewReader(payloadBytes)
-
-	req, err := http.NewRequest(http.MethodPost, url, body)
-	if err != nil {
-		return NewNetworkError(url, err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-
-	return sendRequest(client, req)
+	return sendMessage(context.Background(), newClient(opts), url, nil, msg)
 }
 
 // SendWithContext 使用 Context 發送訊息
 func SendWithContext(ctx context.Context, url string, msg Message, opts ...ClientOption) error {
+	return sendMessage(ctx, newClient(opts), url, nil, msg)
+}
+
+// SendWithProvider 以指定平台的格式發送訊息，provider 為 nil 時依 URL 偵測
+//
+// 適用於無法由 URL 辨識平台的情況（例如經過反向代理的自架服務）。
+func SendWithProvider(ctx context.Context, provider Provider, url string, msg Message, opts ...ClientOption) error {
+	return sendMessage(ctx, newClient(opts), url, provider, msg)
+}
+
+// newClient 建立套用選項的 HTTP 客戶端
+func newClient(opts []ClientOption) *http.Client {
 	client := &http.Client{
 		Timeout: DefaultTimeout,
 	}
@@ -66,19 +54,5 @@ func SendWithContext(ctx context.Context, url string, msg Message, opts ...Clien
 	for _, opt := range opts {
 		opt(client)
 	}
-
-	payloadBytes, err := sonic.Marshal(msg)
-	if err != nil {
-		return NewSerializationError(err)
-	}
-	body := bytes.NewReader(payloadBytes)
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
-	if err != nil {
-		return NewNetworkError(url, err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-
-	return sendRequest(client, req)
+	return client
 }
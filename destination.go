@@ -0,0 +1,79 @@
+package samhook
+
+import (
+	"context"
+)
+
+// Destination 具名的發送目標，包含 URL、平台與訊息預設值
+type Destination struct {
+	// Name 目標名稱
+	Name string
+
+	// URL webhook URL
+	URL string
+
+	// Provider 目標平台，決定訊息限制與編碼格式，為 nil 時依 URL 偵測（無法辨識時使用 SlackProvider）
+	Provider Provider
+
+	// Username、IconURL、IconEmoji、Channel 訊息未設置時使用的預設值
+	Username  string
+	IconURL   string
+	IconEmoji string
+	Channel   string
+
+	// LimitStrategy 訊息超出平台限制時的處理策略
+	LimitStrategy LimitStrategy
+
+	// Retry 重試選項，為 nil 時不重試
+	Retry *RetryOptions
+
+	// RateLimiter 發送前等待的限流器，為 nil 時不限流
+	RateLimiter *RateLimiter
+
+	// ClientOptions HTTP 客戶端選項
+	ClientOptions []ClientOption
+}
+
+// Send 套用預設值與平台限制後發送訊息
+func (d *Destination) Send(ctx context.Context, msg Message) error {
+	provider := resolveProvider(d.URL, d.Provider)
+	messages, err := ApplyLimits(d.applyDefaults(msg), LimitOptions{
+		Provider: provider,
+		Strategy: d.LimitStrategy,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, m := range messages {
+		if d.RateLimiter != nil {
+			if err := d.RateLimiter.Wait(ctx); err != nil {
+				return NewNetworkError(d.URL, err)
+			}
+		}
+		if d.Retry != nil {
+			err = sendWithRetry(ctx, d.URL, provider, m, *d.Retry, d.ClientOptions)
+		} else {
+			err = sendMessage(ctx, newClient(d.ClientOptions), d.URL, provider, m)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyDefaults 以目標的預設值補上訊息中未設置的欄位
+func (d *Destination) applyDefaults(msg Message) Message {
+	if msg.Username == "" {
+		msg.Username = d.Username
+	}
+	if msg.IconURL == "" && msg.IconEmoji == "" {
+		msg.IconURL = d.IconURL
+		msg.IconEmoji = d.IconEmoji
+	}
+	if msg.Channel == "" {
+		msg.Channel = d.Channel
+	}
+	return msg
+}
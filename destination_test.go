@@ -0,0 +1,87 @@
+package samhook
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDestination_SendAppliesDefaults(t *testing.T) {
+	url, received := collectMessages(t)
+	dest := &Destination{
+		Name:      "ops",
+		URL:       url,
+		Username:  "ops-bot",
+		IconEmoji: ":fire:",
+		Channel:   "#ops",
+	}
+
+	if err := dest.Send(context.Background(), Message{Text: "hello", Channel: "#override"}); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	msgs := received()
+	if len(msgs) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(msgs))
+	}
+	got := msgs[0]
+	if got.Username != "ops-bot" || got.IconEmoji != ":fire:" || got.Channel != "#override" {
+		t.Errorf("unexpected message: %+v", got)
+	}
+}
+
+func TestDestination_SendWithLimitsAndRetry(t *testing.T) {
+	attempts := 0
+	server := mockWebhookServer(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	dest := &Destination{
+		URL:           server.URL,
+		Provider:      DiscordProvider{},
+		LimitStrategy: LimitSplit,
+		Retry:         &RetryOptions{MaxRetries: 1, Interval: time.Millisecond},
+	}
+	msg := Message{Text: strings.Repeat("a ", 1500)}
+	if err := dest.Send(context.Background(), msg); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	// 第一則重試一次，第二則直接成功
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestProviderByName(t *testing.T) {
+	if p, ok := ProviderByName("Mattermost"); !ok || p.Name() != ProviderNameMattermost {
+		t.Errorf("expected mattermost provider, got %v, %v", p, ok)
+	}
+	if _, ok := ProviderByName("unknown"); ok {
+		t.Error("unknown provider should not be found")
+	}
+}
+
+func TestSendWithRetryContext_Cancelled(t *testing.T) {
+	server := mockWebhookServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := SendWithRetryContext(ctx, server.URL, createTestMessage(), RetryOptions{MaxRetries: 5, Interval: time.Second})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("retry did not stop on context cancellation, took %v", elapsed)
+	}
+}
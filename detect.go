@@ -0,0 +1,146 @@
+package samhook
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// WebhookInfo 由 webhook URL 解析出的平台識別資訊
+type WebhookInfo struct {
+	// ProviderName 平台名稱（ProviderName* 常數），無法辨識時為空字串
+	ProviderName string
+
+	// Workspace 工作區識別碼（Slack team、Teams tenant）
+	Workspace string
+
+	// ID webhook 或目標識別碼（Slack channel、Discord webhook、Google Chat space 等）
+	ID string
+
+	// Token webhook 密鑰（Slack secret、Discord token、Telegram bot token 等）
+	Token string
+}
+
+var (
+	mattermostHookPath = regexp.MustCompile(`/hooks/([a-z0-9]{26})/?$`)
+	discordHookPath    = regexp.MustCompile(`^/api(?:/v\d+)?/webhooks/(\d+)/([^/]+)`)
+	teamsHookPath      = regexp.MustCompile(`^/webhook(?:b2)?/([^/@]+)@([^/]+)/IncomingWebhook/([^/]+)`)
+	workflowPath       = regexp.MustCompile(`/workflows/([^/]+)/triggers/`)
+	googleChatPath     = regexp.MustCompile(`^/v1/spaces/([^/]+)/messages$`)
+	feishuHookPath     = regexp.MustCompile(`^/open-apis/bot/v2/hook/([^/]+)$`)
+	telegramBotPath    = regexp.MustCompile(`^/bot((\d+):[^/]+)/`)
+)
+
+// DetectProvider 依 webhook URL 辨識平台並解析識別碼
+//
+// 返回的 Provider 為該名稱已註冊的平台，平台未註冊或無法辨識時為 nil。
+// 自架的 Mattermost 與 Zulip 依路徑辨識，使用反向代理等自訂路徑時請明確
+// 指定平台（例如 SendWithProvider）。Rocket.Chat 的 /hooks/<id>/<token> 與
+// 許多 Slack 相容服務的路徑相同，不會自動辨識，需明確指定 RocketChatProvider。
+func DetectProvider(webhookURL string) (Provider, WebhookInfo) {
+	info := detectWebhook(webhookURL)
+	if info.ProviderName == "" {
+		return nil, info
+	}
+	provider, _ := ProviderByName(info.ProviderName)
+	return provider, info
+}
+
+// detectWebhook 解析 URL 的主機與路徑
+func detectWebhook(webhookURL string) WebhookInfo {
+	u, err := url.Parse(webhookURL)
+	if err != nil || u.Host == "" {
+		return WebhookInfo{}
+	}
+	host := strings.ToLower(u.Hostname())
+	path := u.EscapedPath()
+	query := u.Query()
+
+	switch {
+	case host == "hooks.slack.com":
+		parts := strings.Split(strings.Trim(path, "/"), "/")
+		info := WebhookInfo{ProviderName: ProviderNameSlack}
+		if len(parts) == 4 && parts[0] == "services" {
+			info.Workspace, info.ID, info.Token = parts[1], parts[2], parts[3]
+		} else if len(parts) >= 3 {
+			// workflows 與 triggers：/<kind>/<team>/<id>/.../<token>
+			info.Workspace, info.ID, info.Token = parts[1], parts[2], parts[len(parts)-1]
+		}
+		return info
+
+	case isDiscordHost(host):
+		if m := discordHookPath.FindStringSubmatch(path); m != nil {
+			return WebhookInfo{ProviderName: ProviderNameDiscord, ID: m[1], Token: m[2]}
+		}
+
+	case hasDomain(host, "webhook.office.com") || hasDomain(host, "outlook.office.com") || hasDomain(host, "outlook.office365.com"):
+		if m := teamsHookPath.FindStringSubmatch(path); m != nil {
+			return WebhookInfo{ProviderName: ProviderNameTeams, Workspace: m[2], ID: m[3]}
+		}
+
+	case hasDomain(host, "logic.azure.com") || hasDomain(host, "api.powerplatform.com"):
+		// Power Automate 的 Teams workflow
+		if m := workflowPath.FindStringSubmatch(path); m != nil {
+			return WebhookInfo{ProviderName: ProviderNameTeams, ID: m[1], Token: query.Get("sig")}
+		}
+
+	case host == "chat.googleapis.com":
+		if m := googleChatPath.FindStringSubmatch(path); m != nil {
+			return WebhookInfo{ProviderName: ProviderNameGoogleChat, ID: m[1], Token: query.Get("token")}
+		}
+
+	case host == "oapi.dingtalk.com":
+		if path == "/robot/send" {
+			return WebhookInfo{ProviderName: ProviderNameDingTalk, Token: query.Get("access_token")}
+		}
+
+	case host == "open.feishu.cn" || host == "open.larksuite.com":
+		if m := feishuHookPath.FindStringSubmatch(path); m != nil {
+			return WebhookInfo{ProviderName: ProviderNameFeishu, ID: m[1]}
+		}
+
+	case host == "qyapi.weixin.qq.com":
+		if path == "/cgi-bin/webhook/send" {
+			return WebhookInfo{ProviderName: ProviderNameWeCom, Token: query.Get("key")}
+		}
+
+	case host == "api.telegram.org":
+		if m := telegramBotPath.FindStringSubmatch(path); m != nil {
+			return WebhookInfo{ProviderName: ProviderNameTelegram, ID: m[2], Token: m[1]}
+		}
+	}
+
+	// 自架平台依路徑辨識
+	switch {
+	case strings.HasSuffix(path, "/api/v1/external/slack_incoming"):
+		return WebhookInfo{ProviderName: ProviderNameZulip, Token: query.Get("api_key")}
+	case mattermostHookPath.MatchString(path):
+		m := mattermostHookPath.FindStringSubmatch(path)
+		return WebhookInfo{ProviderName: ProviderNameMattermost, ID: m[1]}
+	}
+	return WebhookInfo{}
+}
+
+// isDiscordHost 判斷是否為 Discord（含 ptb、canary 與舊網域）
+func isDiscordHost(host string) bool {
+	for _, domain := range []string{"discord.com", "discordapp.com"} {
+		if host == domain || host == "ptb."+domain || host == "canary."+domain {
+			return true
+		}
+	}
+	return false
+}
+
+// hasDomain 判斷 host 是否為 domain 或其子網域
+func hasDomain(host, domain string) bool {
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
+
+// resolveProvider 返回明確指定的平台，為 nil 時依 URL 偵測
+func resolveProvider(webhookURL string, provider Provider) Provider {
+	if provider != nil {
+		return provider
+	}
+	provider, _ = DetectProvider(webhookURL)
+	return provider
+}
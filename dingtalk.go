@@ -0,0 +1,207 @@
+package samhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/circleyu/samhook/format"
+)
+
+// DingTalkProvider 釘釘自訂機器人
+//
+// 訊息編碼為 markdown；設置 Secret 時自動在 URL 加入 timestamp 與 sign 簽章。
+// 含有 slice 欄位，以指標（&DingTalkProvider{}）使用，讓平台值可以互相比較。
+type DingTalkProvider struct {
+	// Secret 加簽密鑰（SEC 開頭），為空時不簽章
+	Secret string
+
+	// AtMobiles、AtUserIDs 要 @ 的成員，會自動附加到訊息文字
+	AtMobiles []string
+	AtUserIDs []string
+
+	// AtAll 是否 @ 所有人
+	AtAll bool
+}
+
+// 釘釘的限流錯誤代碼（每分鐘超過 20 則）
+const dingTalkRateLimitCode = 130101
+
+// Name 返回平台名稱
+func (*DingTalkProvider) Name() string { return ProviderNameDingTalk }
+
+// Limits 返回釘釘的訊息限制
+func (*DingTalkProvider) Limits() Limits {
+	return Limits{
+		MaxTextLength:     20000,
+		MaxAttachmentText: 20000,
+	}
+}
+
+type dingTalkPayload struct {
+	MsgType  string           `json:"msgtype"`
+	Markdown dingTalkMarkdown `json:"markdown"`
+	At       *dingTalkAt      `json:"at,omitempty"`
+}
+
+type dingTalkMarkdown struct {
+	Title string `json:"title"`
+	Text  string `json:"text"`
+}
+
+type dingTalkAt struct {
+	AtMobiles []string `json:"atMobiles,omitempty"`
+	AtUserIDs []string `json:"atUserIds,omitempty"`
+	IsAtAll   bool     `json:"isAtAll,omitempty"`
+}
+
+// Encode 將訊息編碼為釘釘 markdown 訊息
+func (p *DingTalkProvider) Encode(webhookURL string, msg Message) ([]byte, error) {
+	text := markdownMessage(msg, true)
+	var mentions []string
+	for _, m := range p.AtMobiles {
+		mentions = append(mentions, "@"+m)
+	}
+	for _, id := range p.AtUserIDs {
+		mentions = append(mentions, "@"+id)
+	}
+	if len(mentions) > 0 {
+		text += "\n\n" + strings.Join(mentions, " ")
+	}
+
+	payload := dingTalkPayload{
+		MsgType:  "markdown",
+		Markdown: dingTalkMarkdown{Title: messageTitle(msg), Text: text},
+	}
+	if len(p.AtMobiles) > 0 || len(p.AtUserIDs) > 0 || p.AtAll {
+		payload.At = &dingTalkAt{AtMobiles: p.AtMobiles, AtUserIDs: p.AtUserIDs, IsAtAll: p.AtAll}
+	}
+
+	data, err := sonic.Marshal(payload)
+	if err != nil {
+		return nil, NewSerializationError(err)
+	}
+	return data, nil
+}
+
+// ModifyRequest 設置 Secret 時加入 timestamp 與 sign 查詢參數
+func (p *DingTalkProvider) ModifyRequest(req *http.Request) error {
+	if p.Secret == "" {
+		return nil
+	}
+	timestamp := time.Now().UnixMilli()
+	query := req.URL.Query()
+	query.Set("timestamp", strconv.FormatInt(timestamp, 10))
+	query.Set("sign", dingTalkSign(p.Secret, timestamp))
+	req.URL.RawQuery = query.Encode()
+	return nil
+}
+
+// CheckResponse 釘釘失敗時仍返回 HTTP 200，依 errcode 判斷
+func (*DingTalkProvider) CheckResponse(webhookURL string, statusCode int, body []byte) error {
+	return checkErrcodeResponse(webhookURL, statusCode, body, dingTalkRateLimitCode)
+}
+
+// dingTalkSign 計算簽章：HMAC-SHA256(secret, timestamp + "\n" + secret) 的 base64
+func dingTalkSign(secret string, timestamp int64) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "\n" + secret))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// errcodeResponse 釘釘與企業微信的回應格式
+type errcodeResponse struct {
+	ErrCode int    `json:"errcode"`
+	ErrMsg  string `json:"errmsg"`
+}
+
+// checkErrcodeResponse 解析 {"errcode":..,"errmsg":..} 回應，rateLimitCodes 視為可重試的限流錯誤
+func checkErrcodeResponse(webhookURL string, statusCode int, body []byte, rateLimitCodes ...int) error {
+	if statusCode != http.StatusOK {
+		return NewAPIError(webhookURL, statusCode, string(body))
+	}
+	var resp errcodeResponse
+	if err := sonic.Unmarshal(body, &resp); err != nil || resp.ErrCode == 0 {
+		return nil
+	}
+	apiErr := NewProviderError(webhookURL, statusCode, string(body), strconv.Itoa(resp.ErrCode), resp.ErrMsg)
+	if slices.Contains(rateLimitCodes, resp.ErrCode) {
+		apiErr.ErrorCode = ErrorCodeAPIRateLimit
+	}
+	return apiErr
+}
+
+// messageTitle 返回訊息的摘要標題（用於通知預覽）
+func messageTitle(msg Message) string {
+	for _, a := range msg.Attachments {
+		if title := firstNonEmpty(a.Title, a.Fallback); title != "" {
+			return title
+		}
+	}
+	title, _, _ := strings.Cut(format.ToMarkdown(msg.Text), "\n")
+	return truncateRunes(title, 64, DefaultTruncateMarker)
+}
+
+// markdownMessage 將訊息轉換為 Markdown，colored 為 true 時以 <font> 標籤顯示 attachment 顏色
+func markdownMessage(msg Message, colored bool) string {
+	var parts []string
+	if msg.Text != "" {
+		parts = append(parts, format.ToMarkdown(msg.Text))
+	}
+	for _, a := range msg.Attachments {
+		parts = append(parts, markdownAttachment(a, colored))
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// markdownAttachment 將 attachment 轉換為 Markdown 區段
+func markdownAttachment(a Attachment, colored bool) string {
+	var lines []string
+	if a.Pretext != "" {
+		lines = append(lines, format.ToMarkdown(a.Pretext))
+	}
+	if a.AuthorName != "" {
+		lines = append(lines, markdownLink(a.AuthorName, a.AuthorLink))
+	}
+	if a.Title != "" {
+		title := markdownLink(a.Title, a.TitleLink)
+		if colored {
+			if color, ok := colorValue(a.Color); ok {
+				title = fmt.Sprintf(`<font color="#%06X">%s</font>`, color, title)
+			}
+		}
+		lines = append(lines, "#### "+title)
+	}
+	if a.Text != "" {
+		lines = append(lines, format.ToMarkdown(a.Text))
+	}
+	for _, f := range a.Fields {
+		lines = append(lines, fmt.Sprintf("- **%s**: %s", f.Title, format.ToMarkdown(f.Value)))
+	}
+	if a.ImageURL != "" {
+		lines = append(lines, "![]("+a.ImageURL+")")
+	}
+	if a.Footer != "" {
+		lines = append(lines, "> "+a.Footer)
+	}
+	if len(lines) == 0 {
+		lines = append(lines, a.Fallback)
+	}
+	// 部分平台的 Markdown 不會將單一換行視為斷行
+	return strings.Join(lines, "\n\n")
+}
+
+// markdownLink 有連結時返回 Markdown 連結
+func markdownLink(text, link string) string {
+	if link == "" {
+		return text
+	}
+	return "[" + text + "](" + link + ")"
+}
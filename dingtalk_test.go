@@ -0,0 +1,85 @@
+package samhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/bytedance/sonic"
+)
+
+func TestDingTalkSign(t *testing.T) {
+	// 與官方文件相同的演算法計算出的參考值
+	if got := dingTalkSign("SECabc", 1700000000000); got != "jcUpW0QmtKduN03n4JqQ0PBosVjqnM8gU7fIIvsDmCM=" {
+		t.Errorf("dingTalkSign() = %s", got)
+	}
+}
+
+func TestDingTalkProvider_Encode(t *testing.T) {
+	msg := Message{
+		Text: "deploy *finished*",
+		Attachments: []Attachment{{
+			Color:  Danger,
+			Title:  "api",
+			Fields: []Field{{Title: "Region", Value: "cn-north-1"}},
+		}},
+	}
+	data, err := (&DingTalkProvider{AtMobiles: []string{"13800000000"}}).Encode("", msg)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	var payload dingTalkPayload
+	if err := sonic.Unmarshal(data, &payload); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if payload.MsgType != "markdown" || payload.Markdown.Title != "api" {
+		t.Errorf("unexpected payload: %s", data)
+	}
+	for _, want := range []string{"deploy **finished**", `<font color="#FF0000">api</font>`, "- **Region**: cn-north-1", "@13800000000"} {
+		if !strings.Contains(payload.Markdown.Text, want) {
+			t.Errorf("markdown missing %q:\n%s", want, payload.Markdown.Text)
+		}
+	}
+	if payload.At == nil || payload.At.AtMobiles[0] != "13800000000" {
+		t.Errorf("unexpected at: %+v", payload.At)
+	}
+}
+
+func TestDingTalkProvider_Send(t *testing.T) {
+	secret := "SECtest"
+	server := mockWebhookServer(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		timestamp, _ := strconv.ParseInt(query.Get("timestamp"), 10, 64)
+		if query.Get("access_token") != "tok" || query.Get("sign") != dingTalkSign(secret, timestamp) {
+			w.Write([]byte(`{"errcode":310000,"errmsg":"sign not match"}`))
+			return
+		}
+		w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+	})
+
+	provider := &DingTalkProvider{Secret: secret}
+	if err := SendWithProvider(context.Background(), provider, server.URL+"?access_token=tok", createTestMessage()); err != nil {
+		t.Fatalf("SendWithProvider() error = %v", err)
+	}
+
+	// 未簽章時返回 HTTP 200 但 errcode 不為 0
+	err := SendWithProvider(context.Background(), &DingTalkProvider{}, server.URL+"?access_token=tok", createTestMessage())
+	var webhookErr *WebhookError
+	if !errors.As(err, &webhookErr) || webhookErr.ProviderCode != "310000" {
+		t.Fatalf("expected errcode error, got %v", err)
+	}
+	if isRetryable(webhookErr) {
+		t.Error("signature error should not be retryable")
+	}
+}
+
+func TestCheckErrcodeResponse_RateLimit(t *testing.T) {
+	err := checkErrcodeResponse("u", http.StatusOK, []byte(`{"errcode":130101,"errmsg":"send too fast"}`), dingTalkRateLimitCode)
+	var webhookErr *WebhookError
+	if !errors.As(err, &webhookErr) || webhookErr.GetErrorCode() != ErrorCodeAPIRateLimit || !isRetryable(webhookErr) {
+		t.Errorf("expected retryable rate limit error, got %v", err)
+	}
+}
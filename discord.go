@@ -0,0 +1,130 @@
+package samhook
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/bytedance/sonic"
+	"github.com/circleyu/samhook/format"
+)
+
+// discordPayload Discord 原生 webhook 的請求內容
+type discordPayload struct {
+	Content   string         `json:"content,omitempty"`
+	Username  string         `json:"username,omitempty"`
+	AvatarURL string         `json:"avatar_url,omitempty"`
+	Embeds    []discordEmbed `json:"embeds,omitempty"`
+}
+
+type discordEmbed struct {
+	Title       string         `json:"title,omitempty"`
+	URL         string         `json:"url,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Color       int            `json:"color,omitempty"`
+	Author      *discordAuthor `json:"author,omitempty"`
+	Fields      []discordField `json:"fields,omitempty"`
+	Image       *discordImage  `json:"image,omitempty"`
+	Thumbnail   *discordImage  `json:"thumbnail,omitempty"`
+	Footer      *discordFooter `json:"footer,omitempty"`
+}
+
+type discordAuthor struct {
+	Name    string `json:"name"`
+	URL     string `json:"url,omitempty"`
+	IconURL string `json:"icon_url,omitempty"`
+}
+
+type discordField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline,omitempty"`
+}
+
+type discordImage struct {
+	URL string `json:"url"`
+}
+
+type discordFooter struct {
+	Text    string `json:"text"`
+	IconURL string `json:"icon_url,omitempty"`
+}
+
+// Encode 編碼訊息，Slack 相容端點使用原本的格式
+func (DiscordProvider) Encode(webhookURL string, msg Message) ([]byte, error) {
+	var payload any = msg
+	if !isDiscordSlackEndpoint(webhookURL) {
+		payload = discordMessage(msg)
+	}
+	data, err := sonic.Marshal(payload)
+	if err != nil {
+		return nil, NewSerializationError(err)
+	}
+	return data, nil
+}
+
+// CheckResponse Discord 成功時返回 204（?wait=true 時為 200）
+func (DiscordProvider) CheckResponse(webhookURL string, statusCode int, body []byte) error {
+	if statusCode == http.StatusOK || statusCode == http.StatusNoContent {
+		return nil
+	}
+	return NewAPIError(webhookURL, statusCode, string(body))
+}
+
+// isDiscordSlackEndpoint 判斷是否為 Discord 的 Slack 相容端點
+func isDiscordSlackEndpoint(webhookURL string) bool {
+	path, _, _ := strings.Cut(webhookURL, "?")
+	return strings.HasSuffix(strings.TrimSuffix(path, "/"), "/slack")
+}
+
+// discordMessage 將訊息轉換為 Discord 原生格式（attachment 對應 embed）
+func discordMessage(msg Message) discordPayload {
+	payload := discordPayload{
+		Content:   format.ToMarkdown(msg.Text),
+		Username:  msg.Username,
+		AvatarURL: msg.IconURL,
+	}
+	for _, a := range msg.Attachments {
+		embed := discordEmbed{
+			Title: a.Title,
+			URL:   a.TitleLink,
+		}
+		var description []string
+		if a.Pretext != "" {
+			description = append(description, format.ToMarkdown(a.Pretext))
+		}
+		if a.Text != "" {
+			description = append(description, format.ToMarkdown(a.Text))
+		}
+		embed.Description = strings.Join(description, "\n\n")
+		if severityStyled(a) {
+			embed.Color = a.Severity.DiscordColor()
+		} else if color, ok := colorValue(a.Color); ok {
+			embed.Color = color
+		}
+		if a.AuthorName != "" {
+			embed.Author = &discordAuthor{Name: a.AuthorName, URL: a.AuthorLink, IconURL: a.AuthorIcon}
+		}
+		for _, f := range a.Fields {
+			embed.Fields = append(embed.Fields, discordField{
+				Name:   f.Title,
+				Value:  format.ToMarkdown(f.Value),
+				Inline: f.Short,
+			})
+		}
+		if a.ImageURL != "" {
+			embed.Image = &discordImage{URL: a.ImageURL}
+		}
+		if a.ThumbURL != "" {
+			embed.Thumbnail = &discordImage{URL: a.ThumbURL}
+		}
+		if a.Footer != "" {
+			embed.Footer = &discordFooter{Text: a.Footer, IconURL: a.FooterIcon}
+		}
+		// 空的 embed 會被 Discord 拒絕，改用 fallback 文字
+		if embed.Title == "" && embed.Description == "" && len(embed.Fields) == 0 && embed.Image == nil {
+			embed.Description = a.Fallback
+		}
+		payload.Embeds = append(payload.Embeds, embed)
+	}
+	return payload
+}
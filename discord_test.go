@@ -0,0 +1,76 @@
+package samhook
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bytedance/sonic"
+)
+
+func TestDiscordProvider_Encode(t *testing.T) {
+	msg := Message{
+		Username: "bot",
+		IconURL:  "https://example.com/bot.png",
+		Text:     "*deploy* finished",
+		Attachments: []Attachment{{
+			Color:     Danger,
+			Title:     "api",
+			TitleLink: "https://example.com/api",
+			Text:      "2 pods failed",
+			Fields:    []Field{{Title: "Region", Value: "us-east-1", Short: true}},
+			Footer:    "ci",
+		}},
+	}
+
+	data, err := DiscordProvider{}.Encode("https://discord.com/api/webhooks/1/abc", msg)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	var payload discordPayload
+	if err := sonic.Unmarshal(data, &payload); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if payload.Content != "**deploy** finished" || payload.AvatarURL != msg.IconURL {
+		t.Errorf("unexpected content: %+v", payload)
+	}
+	if len(payload.Embeds) != 1 {
+		t.Fatalf("expected 1 embed, got %d", len(payload.Embeds))
+	}
+	embed := payload.Embeds[0]
+	if embed.Color != 0xFF0000 || embed.URL != "https://example.com/api" || embed.Footer.Text != "ci" {
+		t.Errorf("unexpected embed: %+v", embed)
+	}
+	if len(embed.Fields) != 1 || !embed.Fields[0].Inline || embed.Fields[0].Name != "Region" {
+		t.Errorf("unexpected fields: %+v", embed.Fields)
+	}
+
+	// Slack 相容端點保留原本格式
+	data, err = DiscordProvider{}.Encode("https://discord.com/api/webhooks/1/abc/slack", msg)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if !strings.Contains(string(data), `"attachments"`) {
+		t.Errorf("expected Slack payload for /slack endpoint, got %s", data)
+	}
+}
+
+func TestColorValue(t *testing.T) {
+	tests := []struct {
+		color string
+		want  int
+		ok    bool
+	}{
+		{"#FF0000", 0xFF0000, true},
+		{"#0f0", 0x00FF00, true},
+		{"good", 0x00FF00, true},
+		{"DANGER", 0xFF0000, true},
+		{"", 0, false},
+		{"red", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := colorValue(tt.color)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("colorValue(%q) = %#x, %v, want %#x, %v", tt.color, got, ok, tt.want, tt.ok)
+		}
+	}
+}
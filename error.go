@@ -5,6 +5,7 @@ import (
 	"net"
 	"net/url"
 	"strings"
+	"time"
 )
 
 // 錯誤類型常數
@@ -12,6 +13,7 @@ const (
 	ErrorTypeNetwork       = "network"
 	ErrorTypeSerialization = "serialization"
 	ErrorTypeAPI           = "api"
+	ErrorTypeValidation    = "validation"
 	ErrorTypeUnknown       = "unknown"
 )
 
@@ -26,6 +28,12 @@ const (
 	ErrorCodeAPINotFound       = "API_NOT_FOUND"
 	ErrorCodeAPIRateLimit      = "API_RATE_LIMIT"
 	ErrorCodeAPIServerError    = "API_SERVER_ERROR"
+	ErrorCodePayloadTooLarge   = "PAYLOAD_TOO_LARGE"
+	ErrorCodeInvalidMessage    = "INVALID_MESSAGE"
+	ErrorCodeAddressBlocked    = "ADDRESS_BLOCKED"
+
+	ErrorCodeResponseURLExpired   = "RESPONSE_URL_EXPIRED"
+	ErrorCodeResponseURLExhausted = "RESPONSE_URL_EXHAUSTED"
 )
 
 // WebhookError 表示 webhook 操作中的錯誤
@@ -50,6 +58,12 @@ type WebhookError struct {
 
 	// ErrorCode 具體的錯誤代碼（用於更細緻的分類）
 	ErrorCode string
+
+	// ProviderCode 平台返回的錯誤代碼（例如 Google Chat 的 "INVALID_ARGUMENT"）
+	ProviderCode string
+
+	// RetryAfter 平台要求的重試等待時間（來自 Retry-After 標頭或回應內容）
+	RetryAfter time.Duration
 }
 
 // Error 實現 error 介面，提供詳細的錯誤訊息
@@ -100,6 +114,11 @@ func (e *WebhookError) IsAPIError() bool {
 	return e.Type == ErrorTypeAPI
 }
 
+// IsValidationError 判斷是否為驗證錯誤（發送前檢查失敗）
+func (e *WebhookError) IsValidationError() bool {
+	return e.Type == ErrorTypeValidation
+}
+
 // GetStatusCode 返回 HTTP 狀態碼（如果是 API 錯誤）
 func (e *WebhookError) GetStatusCode() int {
 	return e.StatusCode
@@ -198,6 +217,10 @@ func (e *WebhookError) DetailedMessage() string {
 		buf.WriteString(fmt.Sprintf("  URL: %s\n", e.URL))
 	}
 
+	if e.ProviderCode != "" {
+		buf.WriteString(fmt.Sprintf("  Provider Code: %s\n", e.ProviderCode))
+	}
+
 	if e.ResponseBody != "" {
 		buf.WriteString(fmt.Sprintf("  Response: %s\n", e.ResponseBody))
 	}
@@ -258,6 +281,39 @@ func NewAPIError(url string, statusCode int, responseBody string) *WebhookError
 	}
 }
 
+// NewProviderError 創建包含平台錯誤代碼與訊息的 API 錯誤
+func NewProviderError(url string, statusCode int, responseBody, providerCode, message string) *WebhookError {
+	apiErr := NewAPIError(url, statusCode, responseBody)
+	apiErr.ProviderCode = providerCode
+	if message != "" {
+		apiErr.Message = fmt.Sprintf("API error %s: %s", providerCode, message)
+	} else {
+		apiErr.Message = fmt.Sprintf("API error %s", providerCode)
+	}
+	return apiErr
+}
+
+// NewValidationError 創建驗證錯誤
+func NewValidationError(errorCode string, err error) *WebhookError {
+	return &WebhookError{
+		Type:      ErrorTypeValidation,
+		Message:   fmt.Sprintf("validation error: %v", err),
+		Err:       err,
+		ErrorCode: errorCode,
+	}
+}
+
+// NewBlockedAddressError 創建位址被封鎖的錯誤（不可重試）
+func NewBlockedAddressError(url string, err error) *WebhookError {
+	return &WebhookError{
+		Type:      ErrorTypeValidation,
+		Message:   fmt.Sprintf("request blocked: %v", err),
+		Err:       err,
+		URL:       url,
+		ErrorCode: ErrorCodeAddressBlocked,
+	}
+}
+
 // classifyError 分類標準錯誤為 WebhookError
 func classifyError(webhookURL string, err error) *WebhookError {
 	if err == nil {
@@ -0,0 +1,222 @@
+package samhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/circleyu/samhook/format"
+)
+
+// FeishuProvider 飛書（Lark）自訂機器人
+//
+// 只有文字的訊息編碼為 text，含 attachment 時編碼為 interactive 卡片；
+// 設置 Secret 時自動在請求內容加入 timestamp 與 sign 簽章。
+type FeishuProvider struct {
+	// Secret 簽章密鑰，為空時不簽章
+	Secret string
+}
+
+// 飛書的限流錯誤代碼
+const feishuRateLimitCode = 11232
+
+// Name 返回平台名稱
+func (FeishuProvider) Name() string { return ProviderNameFeishu }
+
+// Limits 返回飛書的訊息限制（請求內容上限 20 KB）
+func (FeishuProvider) Limits() Limits {
+	return Limits{
+		MaxTextLength:     20000,
+		MaxAttachmentText: 20000,
+	}
+}
+
+type feishuPayload struct {
+	Timestamp string         `json:"timestamp,omitempty"`
+	Sign      string         `json:"sign,omitempty"`
+	MsgType   string         `json:"msg_type"`
+	Content   *feishuContent `json:"content,omitempty"`
+	Card      *feishuCard    `json:"card,omitempty"`
+}
+
+type feishuContent struct {
+	Text string `json:"text"`
+}
+
+type feishuCard struct {
+	Config   map[string]bool  `json:"config"`
+	Header   *feishuHeader    `json:"header,omitempty"`
+	Elements []map[string]any `json:"elements"`
+}
+
+type feishuHeader struct {
+	Title    feishuText `json:"title"`
+	Template string     `json:"template,omitempty"`
+}
+
+type feishuText struct {
+	Tag     string `json:"tag"`
+	Content string `json:"content"`
+}
+
+// feishuResponse 飛書的回應格式（舊版端點使用 StatusCode）
+type feishuResponse struct {
+	Code          int    `json:"code"`
+	Msg           string `json:"msg"`
+	StatusCode    int    `json:"StatusCode"`
+	StatusMessage string `json:"StatusMessage"`
+}
+
+// Encode 將訊息編碼為飛書格式，設置 Secret 時加入簽章
+func (p FeishuProvider) Encode(webhookURL string, msg Message) ([]byte, error) {
+	payload := feishuMessage(msg)
+	if p.Secret != "" {
+		timestamp := time.Now().Unix()
+		payload.Timestamp = strconv.FormatInt(timestamp, 10)
+		payload.Sign = feishuSign(p.Secret, timestamp)
+	}
+	data, err := sonic.Marshal(payload)
+	if err != nil {
+		return nil, NewSerializationError(err)
+	}
+	return data, nil
+}
+
+// CheckResponse 飛書失敗時仍返回 HTTP 200，依 code 判斷
+func (FeishuProvider) CheckResponse(webhookURL string, statusCode int, body []byte) error {
+	var resp feishuResponse
+	if err := sonic.Unmarshal(body, &resp); err != nil {
+		if statusCode != http.StatusOK {
+			return NewAPIError(webhookURL, statusCode, string(body))
+		}
+		return nil
+	}
+	code, message := resp.Code, resp.Msg
+	if code == 0 {
+		code, message = resp.StatusCode, resp.StatusMessage
+	}
+	if code == 0 && statusCode == http.StatusOK {
+		return nil
+	}
+	if code == 0 {
+		return NewAPIError(webhookURL, statusCode, string(body))
+	}
+	apiErr := NewProviderError(webhookURL, statusCode, string(body), strconv.Itoa(code), message)
+	if code == feishuRateLimitCode {
+		apiErr.ErrorCode = ErrorCodeAPIRateLimit
+	}
+	return apiErr
+}
+
+// feishuSign 計算簽章：以 timestamp + "\n" + secret 為密鑰對空字串做 HMAC-SHA256 的 base64
+func feishuSign(secret string, timestamp int64) string {
+	mac := hmac.New(sha256.New, []byte(strconv.FormatInt(timestamp, 10)+"\n"+secret))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// feishuMessage 將訊息轉換為飛書格式
+func feishuMessage(msg Message) feishuPayload {
+	if len(msg.Attachments) == 0 {
+		return feishuPayload{MsgType: "text", Content: &feishuContent{Text: format.ToMarkdown(msg.Text)}}
+	}
+
+	card := &feishuCard{Config: map[string]bool{"wide_screen_mode": true}}
+	first := msg.Attachments[0]
+	if first.Title != "" {
+		card.Header = &feishuHeader{
+			Title:    feishuText{Tag: "plain_text", Content: first.Title},
+			Template: feishuTemplate(first.Color),
+		}
+	}
+	if msg.Text != "" {
+		card.Elements = append(card.Elements, feishuMarkdown(format.ToMarkdown(msg.Text)))
+	}
+	for i, a := range msg.Attachments {
+		if i > 0 || msg.Text != "" {
+			card.Elements = append(card.Elements, map[string]any{"tag": "hr"})
+		}
+		// 第一個 attachment 的標題已顯示在卡片標題
+		if i == 0 && card.Header != nil {
+			a.Title = ""
+		}
+		card.Elements = append(card.Elements, feishuElements(a)...)
+	}
+	return feishuPayload{MsgType: "interactive", Card: card}
+}
+
+// feishuElements 將 attachment 轉換為卡片元素
+func feishuElements(a Attachment) []map[string]any {
+	var elements []map[string]any
+	var lines []string
+	if a.Pretext != "" {
+		lines = append(lines, format.ToMarkdown(a.Pretext))
+	}
+	if a.AuthorName != "" {
+		lines = append(lines, markdownLink(a.AuthorName, a.AuthorLink))
+	}
+	if a.Title != "" {
+		lines = append(lines, "**"+markdownLink(a.Title, a.TitleLink)+"**")
+	}
+	if a.Text != "" {
+		lines = append(lines, format.ToMarkdown(a.Text))
+	}
+	if len(lines) > 0 {
+		elements = append(elements, feishuMarkdown(strings.Join(lines, "\n")))
+	}
+
+	if len(a.Fields) > 0 {
+		fields := make([]map[string]any, len(a.Fields))
+		for i, f := range a.Fields {
+			fields[i] = map[string]any{
+				"is_short": f.Short,
+				"text":     feishuText{Tag: "lark_md", Content: "**" + f.Title + "**\n" + format.ToMarkdown(f.Value)},
+			}
+		}
+		elements = append(elements, map[string]any{"tag": "div", "fields": fields})
+	}
+	// 卡片圖片需要先上傳取得 img_key，這裡改為連結
+	if a.ImageURL != "" {
+		elements = append(elements, feishuMarkdown(markdownLink(firstNonEmpty(a.Fallback, a.ImageURL), a.ImageURL)))
+	}
+	if a.TitleLink != "" {
+		elements = append(elements, map[string]any{"tag": "action", "actions": []map[string]any{{
+			"tag":  "button",
+			"text": feishuText{Tag: "plain_text", Content: "Open"},
+			"url":  a.TitleLink,
+			"type": "default",
+		}}})
+	}
+	if a.Footer != "" {
+		elements = append(elements, map[string]any{"tag": "note", "elements": []feishuText{{Tag: "plain_text", Content: a.Footer}}})
+	}
+	if len(elements) == 0 && a.Fallback != "" {
+		elements = append(elements, feishuMarkdown(a.Fallback))
+	}
+	return elements
+}
+
+// feishuMarkdown 建立 lark_md 文字元素
+func feishuMarkdown(content string) map[string]any {
+	return map[string]any{"tag": "div", "text": feishuText{Tag: "lark_md", Content: content}}
+}
+
+// feishuTemplate 將 attachment 顏色對應為卡片標題樣式
+func feishuTemplate(color string) string {
+	switch strings.ToLower(color) {
+	case "":
+		return ""
+	case "good", strings.ToLower(Good):
+		return "green"
+	case "warning", strings.ToLower(Warning):
+		return "orange"
+	case "danger", strings.ToLower(Danger):
+		return "red"
+	default:
+		return "blue"
+	}
+}
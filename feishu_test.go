@@ -0,0 +1,72 @@
+package samhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/bytedance/sonic"
+)
+
+func TestFeishuSign(t *testing.T) {
+	if got := feishuSign("secret", 1700000000); got != "fiWS2+gh28DOydAv7hzONH/mDn9+b1Y4Y5ivXWXy8vA=" {
+		t.Errorf("feishuSign() = %s", got)
+	}
+}
+
+func TestFeishuProvider_Encode(t *testing.T) {
+	tests := []struct {
+		name    string
+		msg     Message
+		msgType string
+	}{
+		{"純文字", Message{Text: "hello"}, "text"},
+		{"含 attachment", Message{Text: "hello", Attachments: []Attachment{{Title: "api", Color: Good, Text: "ok"}}}, "interactive"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := FeishuProvider{}.Encode("", tt.msg)
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			var payload feishuPayload
+			if err := sonic.Unmarshal(data, &payload); err != nil {
+				t.Fatalf("invalid JSON: %v", err)
+			}
+			if payload.MsgType != tt.msgType {
+				t.Errorf("msg_type = %s, want %s", payload.MsgType, tt.msgType)
+			}
+			if payload.Card != nil && (payload.Card.Header == nil || payload.Card.Header.Template != "green") {
+				t.Errorf("unexpected card header: %s", data)
+			}
+		})
+	}
+}
+
+func TestFeishuProvider_Send(t *testing.T) {
+	secret := "secret"
+	server := mockWebhookServer(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var payload feishuPayload
+		sonic.Unmarshal(body, &payload)
+		timestamp, _ := strconv.ParseInt(payload.Timestamp, 10, 64)
+		if payload.Sign == "" || payload.Sign != feishuSign(secret, timestamp) {
+			w.Write([]byte(`{"code":19021,"msg":"sign match fail or timestamp is not within one hour from current time","data":{}}`))
+			return
+		}
+		w.Write([]byte(`{"code":0,"msg":"success","data":{}}`))
+	})
+
+	if err := SendWithProvider(context.Background(), FeishuProvider{Secret: secret}, server.URL, createTestMessage()); err != nil {
+		t.Fatalf("SendWithProvider() error = %v", err)
+	}
+
+	err := SendWithProvider(context.Background(), FeishuProvider{}, server.URL, createTestMessage())
+	var webhookErr *WebhookError
+	if !errors.As(err, &webhookErr) || webhookErr.ProviderCode != "19021" {
+		t.Errorf("expected code 19021 error, got %v", err)
+	}
+}
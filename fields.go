@@ -0,0 +1,252 @@
+package samhook
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// FieldOptions FieldsFrom 的格式選項
+type FieldOptions struct {
+	// TimeLayout time.Time 的格式，為空時使用 time.RFC3339
+	TimeLayout string
+
+	// Location 轉換 time.Time 的時區，為 nil 時保留原本的時區
+	Location *time.Location
+
+	// DurationPrecision time.Duration 四捨五入的精度（例如 time.Second），為 0 時不處理
+	DurationPrecision time.Duration
+
+	// OmitEmpty 省略所有空值欄位（等同每個欄位都設置 omitempty）
+	OmitEmpty bool
+
+	// ShortLength 值的長度（字元數）不超過此值時設為 short，為 0 時只依標籤判斷
+	ShortLength int
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+	stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	errorType    = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// FieldsFrom 將 struct 或 map 轉換為 attachment 的欄位
+//
+// struct 依宣告順序輸出公開欄位，嵌入的 struct 會展開；map 依 key 排序。
+// 欄位以 `samhook:"Title,short,omitempty"` 標籤設置標題與選項，"-" 表示略過，
+// 未設置標題時使用欄位名稱。time.Time 依 TimeLayout 格式化，time.Duration
+// 以 String() 表示，浮點數不使用科學記號，slice 以 ", " 連接。
+func FieldsFrom(v any, opts FieldOptions) ([]Field, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil, nil
+		}
+		rv = rv.Elem()
+	}
+
+	var fields []Field
+	switch rv.Kind() {
+	case reflect.Struct:
+		fields = appendStructFields(fields, rv, opts)
+	case reflect.Map:
+		fields = appendMapFields(fields, rv, opts)
+	default:
+		return nil, NewValidationError(ErrorCodeInvalidMessage,
+			fmt.Errorf("fields require a struct or map, got %s", rv.Kind()))
+	}
+	return fields, nil
+}
+
+// AttachmentFromStruct 以 v 的欄位建立 attachment，title 同時作為 fallback
+func AttachmentFromStruct(title string, v any, opts FieldOptions) (Attachment, error) {
+	fields, err := FieldsFrom(v, opts)
+	if err != nil {
+		return Attachment{}, err
+	}
+	if title == "" && len(fields) == 0 {
+		return Attachment{}, NewValidationError(ErrorCodeInvalidMessage, errors.New("attachment has no title or fields"))
+	}
+	return Attachment{Fallback: title, Title: title, Fields: fields}, nil
+}
+
+// fieldTag 解析後的 samhook 標籤
+type fieldTag struct {
+	title     string
+	short     bool
+	omitEmpty bool
+	skip      bool
+}
+
+// parseFieldTag 解析 `samhook:"Title,short,omitempty"` 標籤
+func parseFieldTag(sf reflect.StructField) fieldTag {
+	tag, ok := sf.Tag.Lookup("samhook")
+	if tag == "-" {
+		return fieldTag{skip: true}
+	}
+	parts := strings.Split(tag, ",")
+	ft := fieldTag{title: parts[0]}
+	if !ok || ft.title == "" {
+		ft.title = sf.Name
+	}
+	for _, opt := range parts[1:] {
+		switch strings.TrimSpace(opt) {
+		case "short":
+			ft.short = true
+		case "omitempty":
+			ft.omitEmpty = true
+		}
+	}
+	return ft
+}
+
+// appendStructFields 依宣告順序加入 struct 的公開欄位
+func appendStructFields(fields []Field, rv reflect.Value, opts FieldOptions) []Field {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		ft := parseFieldTag(sf)
+		if ft.skip {
+			continue
+		}
+		fv := rv.Field(i)
+
+		// 未設置標籤的嵌入 struct 展開為外層的欄位（與 encoding/json 相同，包含未公開的類型）
+		if sf.Anonymous && sf.Tag.Get("samhook") == "" {
+			if fv.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					continue
+				}
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct && fv.Type() != timeType {
+				fields = appendStructFields(fields, fv, opts)
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		fields = appendField(fields, ft, fv, opts)
+	}
+	return fields
+}
+
+// appendMapFields 依 key 排序加入 map 的項目
+func appendMapFields(fields []Field, rv reflect.Value, opts FieldOptions) []Field {
+	type entry struct {
+		key   string
+		value reflect.Value
+	}
+	entries := make([]entry, 0, rv.Len())
+	iter := rv.MapRange()
+	for iter.Next() {
+		entries = append(entries, entry{key: formatValue(iter.Key(), opts), value: iter.Value()})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].key < entries[j].key })
+
+	for _, e := range entries {
+		fields = appendField(fields, fieldTag{title: e.key}, e.value, opts)
+	}
+	return fields
+}
+
+// appendField 格式化值並加入欄位（omitempty 時略過空字串與零值）
+func appendField(fields []Field, ft fieldTag, fv reflect.Value, opts FieldOptions) []Field {
+	value := formatValue(fv, opts)
+	if (ft.omitEmpty || opts.OmitEmpty) && (value == "" || fv.IsZero()) {
+		return fields
+	}
+	short := ft.short || (opts.ShortLength > 0 && utf8.RuneCountInString(value) <= opts.ShortLength)
+	return append(fields, Field{Title: ft.title, Value: value, Short: short})
+}
+
+// formatValue 將值格式化為欄位內容，nil 與零值時間返回空字串
+func formatValue(rv reflect.Value, opts FieldOptions) string {
+	for {
+		if !rv.IsValid() {
+			return ""
+		}
+		if (rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface) && rv.IsNil() {
+			return ""
+		}
+		if s, ok := formatSpecial(rv, opts); ok {
+			return s
+		}
+		if rv.Kind() != reflect.Pointer && rv.Kind() != reflect.Interface {
+			break
+		}
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String()
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10)
+	case reflect.Float32:
+		return strconv.FormatFloat(rv.Float(), 'f', -1, 32)
+	case reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'f', -1, 64)
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8 {
+			return string(rv.Bytes())
+		}
+		parts := make([]string, 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			if s := formatValue(rv.Index(i), opts); s != "" {
+				parts = append(parts, s)
+			}
+		}
+		return strings.Join(parts, ", ")
+	}
+	if rv.CanInterface() {
+		return fmt.Sprint(rv.Interface())
+	}
+	return ""
+}
+
+// formatSpecial 格式化時間、時間長度、error 與 fmt.Stringer
+func formatSpecial(rv reflect.Value, opts FieldOptions) (string, bool) {
+	switch rv.Type() {
+	case timeType:
+		t := rv.Interface().(time.Time)
+		if t.IsZero() {
+			return "", true
+		}
+		if opts.Location != nil {
+			t = t.In(opts.Location)
+		}
+		layout := opts.TimeLayout
+		if layout == "" {
+			layout = time.RFC3339
+		}
+		return t.Format(layout), true
+	case durationType:
+		d := time.Duration(rv.Int())
+		if opts.DurationPrecision > 0 {
+			d = d.Round(opts.DurationPrecision)
+		}
+		return d.String(), true
+	}
+	if !rv.CanInterface() || rv.Kind() == reflect.Interface {
+		return "", false
+	}
+	if rv.Type().Implements(errorType) {
+		return rv.Interface().(error).Error(), true
+	}
+	if rv.Type().Implements(stringerType) {
+		return rv.Interface().(fmt.Stringer).String(), true
+	}
+	return "", false
+}
@@ -0,0 +1,143 @@
+package samhook
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testStatus int
+
+func (s testStatus) String() string {
+	if s == 0 {
+		return "ok"
+	}
+	return "failed"
+}
+
+type testJobMeta struct {
+	Runner string `samhook:"Runner,short"`
+}
+
+type testJobResult struct {
+	testJobMeta
+	Name     string        `samhook:"Job,short"`
+	Status   testStatus    `samhook:",short"`
+	Duration time.Duration `samhook:"Duration,short"`
+	Started  time.Time     `samhook:"Started"`
+	Coverage float64       `samhook:"Coverage,short"`
+	Tags     []string      `samhook:"Tags,omitempty"`
+	Retries  int           `samhook:"Retries,omitempty"`
+	Err      error         `samhook:"Error,omitempty"`
+	Note     *string
+	Secret   string `samhook:"-"`
+	internal string
+}
+
+func TestFieldsFrom(t *testing.T) {
+	started := time.Date(2024, 5, 1, 8, 30, 0, 0, time.UTC)
+	note := "nightly"
+
+	tests := []struct {
+		name    string
+		input   any
+		opts    FieldOptions
+		want    []Field
+		wantErr bool
+	}{
+		{
+			name: "struct 依宣告順序與標籤",
+			input: &testJobResult{
+				testJobMeta: testJobMeta{Runner: "linux-2"},
+				Name:        "build",
+				Status:      1,
+				Duration:    90*time.Second + 400*time.Millisecond,
+				Started:     started,
+				Coverage:    87.5,
+				Err:         errors.New("exit status 1"),
+				Note:        &note,
+				Secret:      "xoxb",
+				internal:    "x",
+			},
+			opts: FieldOptions{DurationPrecision: time.Second},
+			want: []Field{
+				{Title: "Runner", Value: "linux-2", Short: true},
+				{Title: "Job", Value: "build", Short: true},
+				{Title: "Status", Value: "failed", Short: true},
+				{Title: "Duration", Value: "1m30s", Short: true},
+				{Title: "Started", Value: "2024-05-01T08:30:00Z"},
+				{Title: "Coverage", Value: "87.5", Short: true},
+				{Title: "Error", Value: "exit status 1"},
+				{Title: "Note", Value: "nightly"},
+			},
+		},
+		{
+			name:  "時間格式、時區與全部省略空值",
+			input: testJobResult{Started: started, Tags: []string{"go", "ci"}, Retries: 2},
+			opts: FieldOptions{
+				TimeLayout: "2006-01-02 15:04",
+				Location:   time.FixedZone("UTC+8", 8*60*60),
+				OmitEmpty:  true,
+			},
+			want: []Field{
+				{Title: "Started", Value: "2024-05-01 16:30"},
+				{Title: "Tags", Value: "go, ci"},
+				{Title: "Retries", Value: "2"},
+			},
+		},
+		{
+			name:  "map 依 key 排序",
+			input: map[string]any{"zone": "b", "cpu": 0.000001, "count": uint(3), "enabled": true},
+			opts:  FieldOptions{ShortLength: 4},
+			want: []Field{
+				{Title: "count", Value: "3", Short: true},
+				{Title: "cpu", Value: "0.000001"},
+				{Title: "enabled", Value: "true", Short: true},
+				{Title: "zone", Value: "b", Short: true},
+			},
+		},
+		{
+			name:  "nil 指標",
+			input: (*testJobResult)(nil),
+			want:  nil,
+		},
+		{
+			name:    "不支援的類型",
+			input:   []string{"a"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FieldsFrom(tt.input, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FieldsFrom() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FieldsFrom() =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAttachmentFromStruct(t *testing.T) {
+	att, err := AttachmentFromStruct("Deploy", struct {
+		Env     string `samhook:"Environment,short"`
+		Version string `samhook:"Version,short"`
+	}{Env: "prod", Version: "v1.4.2"}, FieldOptions{})
+	if err != nil {
+		t.Fatalf("AttachmentFromStruct() error = %v", err)
+	}
+	if att.Title != "Deploy" || att.Fallback != "Deploy" || len(att.Fields) != 2 {
+		t.Errorf("AttachmentFromStruct() = %+v", att)
+	}
+	if err := (Message{Attachments: []Attachment{att}}).Validate(SlackProvider{}); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	if _, err := AttachmentFromStruct("", struct{}{}, FieldOptions{}); err == nil {
+		t.Error("expected error for empty attachment")
+	}
+}
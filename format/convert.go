@@ -0,0 +1,208 @@
+package format
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	codePattern   = regexp.MustCompile("(?s)```.*?```|`[^`\n]+`")
+	entityPattern = regexp.MustCompile(`<([^<>\n]+)>`)
+	boldPattern   = regexp.MustCompile(`(^|[^\w*])\*([^*\n]+?)\*([^\w*]|$)`)
+	strikePattern = regexp.MustCompile(`(^|[^\w~])~([^~\n]+?)~([^\w~]|$)`)
+)
+
+var slackUnescaper = strings.NewReplacer("&lt;", "<", "&gt;", ">", "&amp;", "&")
+
+// ToMarkdown 將 Slack mrkdwn 轉換為標準 Markdown（用於 Discord、Teams 等平台）
+//
+// 連結、提及與日期標記會轉為可讀文字，粗體與刪除線改用 Markdown 語法，
+// 程式碼區塊內容保持不變。
+func ToMarkdown(mrkdwn string) string {
+	var buf strings.Builder
+	last := 0
+	for _, loc := range codePattern.FindAllStringIndex(mrkdwn, -1) {
+		buf.WriteString(convertText(mrkdwn[last:loc[0]]))
+		buf.WriteString(slackUnescaper.Replace(mrkdwn[loc[0]:loc[1]]))
+		last = loc[1]
+	}
+	buf.WriteString(convertText(mrkdwn[last:]))
+	return buf.String()
+}
+
+// convertText 轉換不含程式碼的文字片段
+func convertText(text string) string {
+	text = entityPattern.ReplaceAllStringFunc(text, func(m string) string {
+		return convertEntity(m[1 : len(m)-1])
+	})
+	text = replaceUntilStable(boldPattern, text, "$1**$2**$3")
+	text = replaceUntilStable(strikePattern, text, "$1~~$2~~$3")
+	return slackUnescaper.Replace(text)
+}
+
+// convertEntity 轉換 <...> 內的連結、提及或日期
+func convertEntity(entity string) string {
+	target, label, hasLabel := strings.Cut(entity, "|")
+
+	switch {
+	case strings.HasPrefix(target, "@"):
+		if hasLabel {
+			return "@" + strings.TrimPrefix(label, "@")
+		}
+		return target
+	case strings.HasPrefix(target, "#"):
+		if hasLabel {
+			return "#" + label
+		}
+		return target
+	case strings.HasPrefix(target, "!"):
+		return convertSpecial(target[1:], label, hasLabel)
+	}
+
+	if hasLabel {
+		return "[" + label + "](" + target + ")"
+	}
+	return target
+}
+
+// convertSpecial 轉換 <!...> 特殊標記
+func convertSpecial(command, label string, hasLabel bool) string {
+	switch command {
+	case "here":
+		return "@here"
+	case "channel":
+		return "@channel"
+	case "everyone":
+		return "@everyone"
+	}
+	if hasLabel {
+		return label
+	}
+	if strings.HasPrefix(command, "subteam^") {
+		return "@" + strings.TrimPrefix(command, "subteam^")
+	}
+	if strings.HasPrefix(command, "date^") {
+		parts := strings.Split(command, "^")
+		if ts, err := strconv.ParseInt(parts[1], 10, 64); err == nil {
+			return time.Unix(ts, 0).UTC().Format(defaultDateFallback)
+		}
+	}
+	return command
+}
+
+// replaceUntilStable 重複替換直到結果不再改變（處理相鄰的匹配）
+func replaceUntilStable(re *regexp.Regexp, text, repl string) string {
+	for {
+		next := re.ReplaceAllString(text, repl)
+		if next == text {
+			return next
+		}
+		text = next
+	}
+}
+
+// entityPrefix 位於開頭的 <...> 標記
+var entityPrefix = regexp.MustCompile(`^<([^<>\n]+)>`)
+
+// inlineStyles mrkdwn 的行內樣式標記
+var inlineStyles = map[byte]func(Formatter, string) string{
+	'*': Formatter.Bold,
+	'_': Formatter.Italic,
+	'~': Formatter.Strike,
+}
+
+// Convert 將 Slack mrkdwn 轉換為 f 的格式，一般文字以 f.Escape 跳脫
+//
+// 粗體、斜體、刪除線、程式碼與連結會轉換為對應的語法，提及與日期標記
+// 轉為可讀文字。適用於需要跳脫的平台（例如 Telegram）。
+func Convert(f Formatter, mrkdwn string) string {
+	var buf strings.Builder
+	last := 0
+	for _, loc := range codePattern.FindAllStringIndex(mrkdwn, -1) {
+		buf.WriteString(convertInline(f, mrkdwn[last:loc[0]]))
+		code := slackUnescaper.Replace(mrkdwn[loc[0]:loc[1]])
+		if strings.HasPrefix(code, "```") {
+			buf.WriteString(f.CodeBlock("", strings.TrimPrefix(code[3:len(code)-3], "\n")))
+		} else {
+			buf.WriteString(f.Code(code[1 : len(code)-1]))
+		}
+		last = loc[1]
+	}
+	buf.WriteString(convertInline(f, mrkdwn[last:]))
+	return buf.String()
+}
+
+// convertInline 轉換不含程式碼的文字片段
+func convertInline(f Formatter, text string) string {
+	var buf, plain strings.Builder
+	flush := func() {
+		buf.WriteString(f.Escape(slackUnescaper.Replace(plain.String())))
+		plain.Reset()
+	}
+
+	for i := 0; i < len(text); {
+		if m := entityPrefix.FindStringSubmatch(text[i:]); m != nil {
+			flush()
+			buf.WriteString(convertEntityTo(f, m[1]))
+			i += len(m[0])
+			continue
+		}
+		if style, ok := inlineStyles[text[i]]; ok {
+			if end := closingDelimiter(text, i); end > 0 {
+				flush()
+				buf.WriteString(style(f, convertInline(f, text[i+1:end])))
+				i = end + 1
+				continue
+			}
+		}
+		plain.WriteByte(text[i])
+		i++
+	}
+	flush()
+	return buf.String()
+}
+
+// convertEntityTo 將 <...> 標記轉換為 f 的連結，提及與日期轉為跳脫後的文字
+func convertEntityTo(f Formatter, entity string) string {
+	if strings.HasPrefix(entity, "@") || strings.HasPrefix(entity, "#") || strings.HasPrefix(entity, "!") {
+		return f.Escape(slackUnescaper.Replace(convertEntity(entity)))
+	}
+	target, label, _ := strings.Cut(entity, "|")
+	return f.Link(slackUnescaper.Replace(target), slackUnescaper.Replace(label))
+}
+
+// closingDelimiter 返回 text[start] 樣式標記的結束位置，不構成樣式時返回 -1
+//
+// 與 Slack 相同：標記前後不能是文字字元，內容不能以空白開始或結束，且不跨行。
+func closingDelimiter(text string, start int) int {
+	delim := text[start]
+	if start > 0 && isWordByte(text[start-1]) {
+		return -1
+	}
+	if start+1 >= len(text) || text[start+1] == ' ' || text[start+1] == delim {
+		return -1
+	}
+	for j := start + 1; j < len(text); j++ {
+		switch text[j] {
+		case '\n':
+			return -1
+		case '<':
+			// 略過連結等標記（URL 中可能包含樣式字元）
+			if m := entityPrefix.FindStringIndex(text[j:]); m != nil {
+				j += m[1] - 1
+			}
+		case delim:
+			if text[j-1] != ' ' && (j+1 == len(text) || !isWordByte(text[j+1])) {
+				return j
+			}
+		}
+	}
+	return -1
+}
+
+// isWordByte 判斷是否為英數字或底線（非 ASCII 字元不視為文字字元）
+func isWordByte(b byte) bool {
+	return b == '_' || b >= '0' && b <= '9' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z'
+}
@@ -0,0 +1,68 @@
+package format
+
+import "testing"
+
+func TestToMarkdown(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"純文字", "hello world", "hello world"},
+		{"粗體", "*bold* and *more*", "**bold** and **more**"},
+		{"刪除線", "~gone~", "~~gone~~"},
+		{"斜體保持不變", "_italic_", "_italic_"},
+		{"連結", "see <https://example.com|the docs>", "see [the docs](https://example.com)"},
+		{"無文字連結", "<https://example.com>", "https://example.com"},
+		{"使用者提及", "<@U123> and <@U456|bob>", "@U123 and @bob"},
+		{"頻道提及", "<#C123|general>", "#general"},
+		{"特殊提及", "<!here> <!channel> <!everyone>", "@here @channel @everyone"},
+		{"使用者群組", "<!subteam^S123|@oncall>", "@oncall"},
+		{"日期", "<!date^1704164645^{date_short}|Jan 2>", "Jan 2"},
+		{"無 fallback 日期", "<!date^1704164645^{date_short}>", "2024-01-02 03:04 UTC"},
+		{"反跳脫", "a &amp; b &lt;c&gt;", "a & b <c>"},
+		{"程式碼不轉換", "`*x*` and ```\n*y* <!here>\n```", "`*x*` and ```\n*y* <!here>\n```"},
+		{"乘法不視為粗體", "2*3*4", "2*3*4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToMarkdown(tt.input); got != tt.want {
+				t.Errorf("ToMarkdown(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name  string
+		f     Formatter
+		input string
+		want  string
+	}{
+		{"HTML 跳脫", TelegramHTML, "a < b & c", "a &lt; b &amp; c"},
+		{"HTML 反跳脫後再跳脫", TelegramHTML, "a &lt; b", "a &lt; b"},
+		{"HTML 樣式", TelegramHTML, "*bold* _it_ ~gone~", "<b>bold</b> <i>it</i> <s>gone</s>"},
+		{"HTML 巢狀樣式", TelegramHTML, "*very _nested_*", "<b>very <i>nested</i></b>"},
+		{"HTML 連結", TelegramHTML, "see <https://example.com/a_b|the docs>", `see <a href="https://example.com/a_b">the docs</a>`},
+		{"HTML 粗體內的連結", TelegramHTML, "*<https://example.com/x_y|docs>*", `<b><a href="https://example.com/x_y">docs</a></b>`},
+		{"HTML 提及", TelegramHTML, "<@U123|bob> <!here>", "@bob @here"},
+		{"HTML 程式碼", TelegramHTML, "`a<b` and ```\nx && y\n```", "<code>a&lt;b</code> and <pre>x &amp;&amp; y</pre>"},
+		{"底線變數名稱", TelegramHTML, "snake_case_name", "snake_case_name"},
+		{"乘法不視為粗體", TelegramHTML, "2*3*4", "2*3*4"},
+		{"空白不構成樣式", TelegramHTML, "a * b * c", "a * b * c"},
+		{"中文相鄰", TelegramHTML, "部署*成功*了", "部署<b>成功</b>了"},
+		{"MarkdownV2 跳脫", TelegramMarkdownV2, "v1.2 *done*!", `v1\.2 *done*\!`},
+		{"MarkdownV2 未成對標記", TelegramMarkdownV2, "5 * 3", `5 \* 3`},
+		{"Mattermost", Mattermost, "*bold* <https://example.com|link>", "**bold** [link](https://example.com)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Convert(tt.f, tt.input); got != tt.want {
+				t.Errorf("Convert(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
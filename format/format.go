@@ -0,0 +1,69 @@
+// Package format 提供建構 Slack mrkdwn、Mattermost Markdown 與 Telegram 訊息文字的工具函數
+package format
+
+import "time"
+
+// Slack 日期格式標記（用於 Formatter.Date）
+const (
+	DateNum         = "{date_num}"
+	Date            = "{date}"
+	DateShort       = "{date_short}"
+	DateLong        = "{date_long}"
+	DatePretty      = "{date_pretty}"
+	DateShortPretty = "{date_short_pretty}"
+	DateLongPretty  = "{date_long_pretty}"
+	Time            = "{time}"
+	TimeSecs        = "{time_secs}"
+	Ago             = "{ago}"
+)
+
+// Formatter 定義訊息文字的格式化方式
+type Formatter interface {
+	// Escape 跳脫特殊字元，使文字按原樣顯示
+	Escape(text string) string
+
+	// Bold 粗體
+	Bold(text string) string
+
+	// Italic 斜體
+	Italic(text string) string
+
+	// Strike 刪除線
+	Strike(text string) string
+
+	// Code 行內程式碼
+	Code(text string) string
+
+	// CodeBlock 程式碼區塊（lang 在不支援語法標示的平台會被忽略）
+	CodeBlock(lang, code string) string
+
+	// Quote 引用區塊
+	Quote(text string) string
+
+	// Link 超連結，text 為空時直接顯示 URL
+	Link(url, text string) string
+
+	// User 提及使用者
+	User(id string) string
+
+	// Channel 提及頻道
+	Channel(id string) string
+
+	// Here 通知頻道中在線的成員
+	Here() string
+
+	// ChannelAll 通知頻道中所有成員
+	ChannelAll() string
+
+	// Everyone 通知工作區中所有成員
+	Everyone() string
+
+	// Date 顯示日期，format 使用 Date* 常數組合
+	Date(t time.Time, format, fallback string) string
+
+	// BulletList 項目清單
+	BulletList(items ...string) string
+}
+
+// defaultDateFallback 未提供 fallback 時的日期顯示格式
+const defaultDateFallback = "2006-01-02 15:04 MST"
@@ -0,0 +1,97 @@
+package format
+
+import (
+	"strings"
+	"time"
+)
+
+// Mattermost Mattermost Markdown 格式（同樣適用於標準 Markdown 平台）
+var Mattermost Formatter = mattermostFormatter{}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"`", "\\`",
+	"*", `\*`,
+	"_", `\_`,
+	"~", `\~`,
+	"[", `\[`,
+	"]", `\]`,
+	"(", `\(`,
+	")", `\)`,
+	"#", `\#`,
+	">", `\>`,
+	"<", `\<`,
+	"|", `\|`,
+)
+
+// mattermostFormatter Mattermost Markdown 實現
+type mattermostFormatter struct{}
+
+func (mattermostFormatter) Escape(text string) string {
+	return markdownEscaper.Replace(text)
+}
+
+func (mattermostFormatter) Bold(text string) string {
+	return "**" + text + "**"
+}
+
+func (mattermostFormatter) Italic(text string) string {
+	return "_" + text + "_"
+}
+
+func (mattermostFormatter) Strike(text string) string {
+	return "~~" + text + "~~"
+}
+
+func (mattermostFormatter) Code(text string) string {
+	return "`" + text + "`"
+}
+
+func (mattermostFormatter) CodeBlock(lang, code string) string {
+	return "```" + lang + "\n" + strings.TrimSuffix(code, "\n") + "\n```"
+}
+
+func (mattermostFormatter) Quote(text string) string {
+	return quoteLines(text)
+}
+
+func (f mattermostFormatter) Link(url, text string) string {
+	if text == "" {
+		return url
+	}
+	return "[" + f.Escape(text) + "](" + url + ")"
+}
+
+// User Mattermost 以使用者名稱提及
+func (mattermostFormatter) User(username string) string {
+	return "@" + strings.TrimPrefix(username, "@")
+}
+
+// Channel Mattermost 以頻道名稱提及
+func (mattermostFormatter) Channel(name string) string {
+	return "~" + strings.TrimPrefix(name, "~")
+}
+
+func (mattermostFormatter) Here() string {
+	return "@here"
+}
+
+func (mattermostFormatter) ChannelAll() string {
+	return "@channel"
+}
+
+func (mattermostFormatter) Everyone() string {
+	return "@all"
+}
+
+// Date Mattermost 不支援日期標記，直接顯示 fallback
+func (mattermostFormatter) Date(t time.Time, format, fallback string) string {
+	if fallback == "" {
+		fallback = t.UTC().Format(defaultDateFallback)
+	}
+	return fallback
+}
+
+func (mattermostFormatter) BulletList(items ...string) string {
+	return bulletList("- ", items)
+}
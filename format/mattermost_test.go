@@ -0,0 +1,40 @@
+package format
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMattermost(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"跳脫", Mattermost.Escape("*a* _b_ [c]"), `\*a\* \_b\_ \[c\]`},
+		{"粗體", Mattermost.Bold("x"), "**x**"},
+		{"斜體", Mattermost.Italic("x"), "_x_"},
+		{"刪除線", Mattermost.Strike("x"), "~~x~~"},
+		{"程式碼區塊", Mattermost.CodeBlock("go", "fmt.Println()"), "```go\nfmt.Println()\n```"},
+		{"連結", Mattermost.Link("https://example.com", "a_b"), `[a\_b](https://example.com)`},
+		{"無文字連結", Mattermost.Link("https://example.com", ""), "https://example.com"},
+		{"使用者", Mattermost.User("@alice"), "@alice"},
+		{"頻道", Mattermost.Channel("town-square"), "~town-square"},
+		{"here", Mattermost.Here(), "@here"},
+		{"channel", Mattermost.ChannelAll(), "@channel"},
+		{"everyone", Mattermost.Everyone(), "@all"},
+		{"日期", Mattermost.Date(ts, DateShort, "Jan 2"), "Jan 2"},
+		{"預設日期 fallback", Mattermost.Date(ts, DateShort, ""), "2024-01-02 03:04 UTC"},
+		{"清單", Mattermost.BulletList("a", "b"), "- a\n- b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %q, want %q", tt.got, tt.want)
+			}
+		})
+	}
+}
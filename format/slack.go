@@ -0,0 +1,110 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Slack Slack mrkdwn 格式
+var Slack Formatter = slackFormatter{}
+
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// slackFormatter Slack mrkdwn 實現
+type slackFormatter struct{}
+
+func (slackFormatter) Escape(text string) string {
+	return slackEscaper.Replace(text)
+}
+
+func (slackFormatter) Bold(text string) string {
+	return "*" + text + "*"
+}
+
+func (slackFormatter) Italic(text string) string {
+	return "_" + text + "_"
+}
+
+func (slackFormatter) Strike(text string) string {
+	return "~" + text + "~"
+}
+
+func (slackFormatter) Code(text string) string {
+	return "`" + text + "`"
+}
+
+// CodeBlock Slack 不支援語法標示，忽略 lang
+func (slackFormatter) CodeBlock(lang, code string) string {
+	return "```\n" + strings.TrimSuffix(code, "\n") + "\n```"
+}
+
+func (slackFormatter) Quote(text string) string {
+	return quoteLines(text)
+}
+
+func (f slackFormatter) Link(url, text string) string {
+	if text == "" {
+		return "<" + url + ">"
+	}
+	return "<" + url + "|" + f.Escape(text) + ">"
+}
+
+func (slackFormatter) User(id string) string {
+	return "<@" + id + ">"
+}
+
+func (slackFormatter) Channel(id string) string {
+	return "<#" + id + ">"
+}
+
+func (slackFormatter) Here() string {
+	return "<!here>"
+}
+
+func (slackFormatter) ChannelAll() string {
+	return "<!channel>"
+}
+
+func (slackFormatter) Everyone() string {
+	return "<!everyone>"
+}
+
+// Date 產生 <!date^ts^format|fallback>，fallback 在不支援的客戶端顯示
+func (f slackFormatter) Date(t time.Time, format, fallback string) string {
+	if fallback == "" {
+		fallback = t.UTC().Format(defaultDateFallback)
+	}
+	return fmt.Sprintf("<!date^%d^%s|%s>", t.Unix(), format, f.Escape(fallback))
+}
+
+func (slackFormatter) BulletList(items ...string) string {
+	return bulletList("• ", items)
+}
+
+// UserGroup 提及使用者群組（Slack 專用）
+func UserGroup(id string) string {
+	return "<!subteam^" + id + ">"
+}
+
+// quoteLines 在每一行前加上引用標記
+func quoteLines(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = "> " + line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// bulletList 以指定前綴組合清單
+func bulletList(bullet string, items []string) string {
+	var buf strings.Builder
+	for i, item := range items {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		buf.WriteString(bullet)
+		buf.WriteString(item)
+	}
+	return buf.String()
+}
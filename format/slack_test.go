@@ -0,0 +1,43 @@
+package format
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSlack(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"跳脫", Slack.Escape("a & <b> c"), "a &amp; &lt;b&gt; c"},
+		{"粗體", Slack.Bold("x"), "*x*"},
+		{"斜體", Slack.Italic("x"), "_x_"},
+		{"刪除線", Slack.Strike("x"), "~x~"},
+		{"行內程式碼", Slack.Code("x"), "`x`"},
+		{"程式碼區塊", Slack.CodeBlock("go", "fmt.Println()\n"), "```\nfmt.Println()\n```"},
+		{"引用", Slack.Quote("a\nb"), "> a\n> b"},
+		{"連結", Slack.Link("https://example.com", "A & B"), "<https://example.com|A &amp; B>"},
+		{"無文字連結", Slack.Link("https://example.com", ""), "<https://example.com>"},
+		{"使用者", Slack.User("U123"), "<@U123>"},
+		{"頻道", Slack.Channel("C123"), "<#C123>"},
+		{"here", Slack.Here(), "<!here>"},
+		{"channel", Slack.ChannelAll(), "<!channel>"},
+		{"everyone", Slack.Everyone(), "<!everyone>"},
+		{"使用者群組", UserGroup("S123"), "<!subteam^S123>"},
+		{"日期", Slack.Date(ts, DateShort+" "+Time, "Jan 2"), "<!date^1704164645^{date_short} {time}|Jan 2>"},
+		{"預設日期 fallback", Slack.Date(ts, Date, ""), "<!date^1704164645^{date}|2024-01-02 03:04 UTC>"},
+		{"清單", Slack.BulletList("a", "b"), "• a\n• b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %q, want %q", tt.got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package format
+
+import (
+	"strings"
+	"time"
+)
+
+// TelegramHTML Telegram Bot API 的 HTML 格式（parse_mode=HTML）
+var TelegramHTML Formatter = telegramHTMLFormatter{}
+
+// TelegramMarkdownV2 Telegram Bot API 的 MarkdownV2 格式（parse_mode=MarkdownV2）
+var TelegramMarkdownV2 Formatter = telegramMarkdownV2Formatter{}
+
+var (
+	htmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+
+	markdownV2Escaper = strings.NewReplacer(
+		`\`, `\\`, "_", `\_`, "*", `\*`, "[", `\[`, "]", `\]`, "(", `\(`, ")", `\)`,
+		"~", `\~`, "`", "\\`", ">", `\>`, "#", `\#`, "+", `\+`, "-", `\-`, "=", `\=`,
+		"|", `\|`, "{", `\{`, "}", `\}`, ".", `\.`, "!", `\!`,
+	)
+
+	// 程式碼內只需跳脫 ` 與 \，連結 URL 內只需跳脫 ) 與 \
+	markdownV2CodeEscaper = strings.NewReplacer(`\`, `\\`, "`", "\\`")
+	markdownV2URLEscaper  = strings.NewReplacer(`\`, `\\`, ")", `\)`)
+)
+
+// telegramHTMLFormatter Telegram HTML 實現
+type telegramHTMLFormatter struct{}
+
+func (telegramHTMLFormatter) Escape(text string) string {
+	return htmlEscaper.Replace(text)
+}
+
+func (telegramHTMLFormatter) Bold(text string) string {
+	return "<b>" + text + "</b>"
+}
+
+func (telegramHTMLFormatter) Italic(text string) string {
+	return "<i>" + text + "</i>"
+}
+
+func (telegramHTMLFormatter) Strike(text string) string {
+	return "<s>" + text + "</s>"
+}
+
+// Code HTML 中的程式碼同樣需要跳脫
+func (f telegramHTMLFormatter) Code(text string) string {
+	return "<code>" + f.Escape(text) + "</code>"
+}
+
+func (f telegramHTMLFormatter) CodeBlock(lang, code string) string {
+	code = f.Escape(strings.TrimSuffix(code, "\n"))
+	if lang == "" {
+		return "<pre>" + code + "</pre>"
+	}
+	return `<pre><code class="language-` + f.Escape(lang) + `">` + code + "</code></pre>"
+}
+
+func (telegramHTMLFormatter) Quote(text string) string {
+	return "<blockquote>" + text + "</blockquote>"
+}
+
+func (f telegramHTMLFormatter) Link(url, text string) string {
+	if text == "" {
+		text = url
+	}
+	return `<a href="` + f.Escape(url) + `">` + f.Escape(text) + "</a>"
+}
+
+// User 數字 ID 以 tg://user 連結提及，其餘視為使用者名稱
+func (f telegramHTMLFormatter) User(id string) string {
+	if isNumeric(id) {
+		return f.Link("tg://user?id="+id, id)
+	}
+	return "@" + f.Escape(strings.TrimPrefix(id, "@"))
+}
+
+func (f telegramHTMLFormatter) Channel(name string) string {
+	return "@" + f.Escape(strings.TrimPrefix(name, "@"))
+}
+
+// Here Telegram 不支援群組通知，顯示為一般文字
+func (telegramHTMLFormatter) Here() string {
+	return "@here"
+}
+
+func (telegramHTMLFormatter) ChannelAll() string {
+	return "@channel"
+}
+
+func (telegramHTMLFormatter) Everyone() string {
+	return "@everyone"
+}
+
+// Date Telegram 不支援日期標記，直接顯示 fallback
+func (f telegramHTMLFormatter) Date(t time.Time, format, fallback string) string {
+	if fallback == "" {
+		fallback = t.UTC().Format(defaultDateFallback)
+	}
+	return f.Escape(fallback)
+}
+
+func (telegramHTMLFormatter) BulletList(items ...string) string {
+	return bulletList("• ", items)
+}
+
+// telegramMarkdownV2Formatter Telegram MarkdownV2 實現
+type telegramMarkdownV2Formatter struct{}
+
+func (telegramMarkdownV2Formatter) Escape(text string) string {
+	return markdownV2Escaper.Replace(text)
+}
+
+func (telegramMarkdownV2Formatter) Bold(text string) string {
+	return "*" + text + "*"
+}
+
+func (telegramMarkdownV2Formatter) Italic(text string) string {
+	return "_" + text + "_"
+}
+
+func (telegramMarkdownV2Formatter) Strike(text string) string {
+	return "~" + text + "~"
+}
+
+func (telegramMarkdownV2Formatter) Code(text string) string {
+	return "`" + markdownV2CodeEscaper.Replace(text) + "`"
+}
+
+func (telegramMarkdownV2Formatter) CodeBlock(lang, code string) string {
+	return "```" + lang + "\n" + markdownV2CodeEscaper.Replace(strings.TrimSuffix(code, "\n")) + "\n```"
+}
+
+func (telegramMarkdownV2Formatter) Quote(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = ">" + line
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (f telegramMarkdownV2Formatter) Link(url, text string) string {
+	if text == "" {
+		text = url
+	}
+	return "[" + f.Escape(text) + "](" + markdownV2URLEscaper.Replace(url) + ")"
+}
+
+// User 數字 ID 以 tg://user 連結提及，其餘視為使用者名稱
+func (f telegramMarkdownV2Formatter) User(id string) string {
+	if isNumeric(id) {
+		return f.Link("tg://user?id="+id, id)
+	}
+	return "@" + f.Escape(strings.TrimPrefix(id, "@"))
+}
+
+func (f telegramMarkdownV2Formatter) Channel(name string) string {
+	return "@" + f.Escape(strings.TrimPrefix(name, "@"))
+}
+
+// Here Telegram 不支援群組通知，顯示為一般文字
+func (telegramMarkdownV2Formatter) Here() string {
+	return "@here"
+}
+
+func (telegramMarkdownV2Formatter) ChannelAll() string {
+	return "@channel"
+}
+
+func (telegramMarkdownV2Formatter) Everyone() string {
+	return "@everyone"
+}
+
+// Date Telegram 不支援日期標記，直接顯示 fallback
+func (f telegramMarkdownV2Formatter) Date(t time.Time, format, fallback string) string {
+	if fallback == "" {
+		fallback = t.UTC().Format(defaultDateFallback)
+	}
+	return f.Escape(fallback)
+}
+
+func (telegramMarkdownV2Formatter) BulletList(items ...string) string {
+	return bulletList("• ", items)
+}
+
+// isNumeric 判斷字串是否只包含數字
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
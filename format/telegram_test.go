@@ -0,0 +1,57 @@
+package format
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTelegramHTML(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"跳脫", TelegramHTML.Escape(`a & <b> "c"`), "a &amp; &lt;b&gt; &quot;c&quot;"},
+		{"粗體", TelegramHTML.Bold("x"), "<b>x</b>"},
+		{"行內程式碼", TelegramHTML.Code("a<b"), "<code>a&lt;b</code>"},
+		{"程式碼區塊", TelegramHTML.CodeBlock("go", "x < y\n"), `<pre><code class="language-go">x &lt; y</code></pre>`},
+		{"連結", TelegramHTML.Link("https://example.com/?a=1&b=2", "A & B"), `<a href="https://example.com/?a=1&amp;b=2">A &amp; B</a>`},
+		{"數字 ID 提及", TelegramHTML.User("123"), `<a href="tg://user?id=123">123</a>`},
+		{"使用者名稱提及", TelegramHTML.User("@bob"), "@bob"},
+		{"日期", TelegramHTML.Date(ts, DateShort, ""), "2024-01-02 03:04 UTC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %q, want %q", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTelegramMarkdownV2(t *testing.T) {
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"跳脫", TelegramMarkdownV2.Escape("v1.2-rc (beta)!"), `v1\.2\-rc \(beta\)\!`},
+		{"粗體", TelegramMarkdownV2.Bold("x"), "*x*"},
+		{"行內程式碼", TelegramMarkdownV2.Code("a`b.c"), "`a\\`b.c`"},
+		{"程式碼區塊", TelegramMarkdownV2.CodeBlock("go", "x\\y"), "```go\nx\\\\y\n```"},
+		{"引用", TelegramMarkdownV2.Quote("a\nb"), ">a\n>b"},
+		{"連結", TelegramMarkdownV2.Link("https://example.com/a_(b)", "docs.v2"), `[docs\.v2](https://example.com/a_(b\))`},
+		{"使用者名稱提及", TelegramMarkdownV2.User("bob_smith"), `@bob\_smith`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %q, want %q", tt.got, tt.want)
+			}
+		})
+	}
+}
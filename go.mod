@@ -2,7 +2,10 @@ module github.com/circleyu/samhook
 
 go 1.25.5
 
-require github.com/bytedance/sonic v1.14.2
+require (
+	github.com/bytedance/sonic v1.14.2
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	github.com/bytedance/gopkg v0.1.3 // indirect
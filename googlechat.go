@@ -0,0 +1,200 @@
+package samhook
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/bytedance/sonic"
+)
+
+// Google Chat 的 messageReplyOption
+const (
+	// GoogleChatReplyFallbackToNewThread 回覆 threadKey 對應的討論串，不存在時建立新討論串
+	GoogleChatReplyFallbackToNewThread = "REPLY_MESSAGE_FALLBACK_TO_NEW_THREAD"
+
+	// GoogleChatReplyOrFail 回覆 threadKey 對應的討論串，不存在時返回錯誤
+	GoogleChatReplyOrFail = "REPLY_MESSAGE_OR_FAIL"
+)
+
+// GoogleChatProvider Google Chat incoming webhook
+//
+// 文字放在 text，attachment 轉換為 cardsV2 卡片。設置 ThreadKey 時訊息會發送到
+// 對應的討論串，可搭配 SendWithProvider 為每則訊息指定不同的討論串。
+type GoogleChatProvider struct {
+	// ThreadKey 討論串識別碼，為空時建立新的討論串
+	ThreadKey string
+
+	// ReplyOption 回覆方式，ThreadKey 不為空且此欄位為空時使用 GoogleChatReplyFallbackToNewThread
+	ReplyOption string
+}
+
+// Name 返回平台名稱
+func (GoogleChatProvider) Name() string { return ProviderNameGoogleChat }
+
+// Limits 返回 Google Chat 的訊息限制
+func (GoogleChatProvider) Limits() Limits {
+	return Limits{
+		MaxTextLength: 4096,
+	}
+}
+
+type googleChatPayload struct {
+	Text    string             `json:"text,omitempty"`
+	CardsV2 []googleChatCardV2 `json:"cardsV2,omitempty"`
+	Thread  *googleChatThread  `json:"thread,omitempty"`
+}
+
+type googleChatThread struct {
+	ThreadKey string `json:"threadKey"`
+}
+
+type googleChatCardV2 struct {
+	CardID string         `json:"cardId"`
+	Card   googleChatCard `json:"card"`
+}
+
+type googleChatCard struct {
+	Header   *googleChatHeader   `json:"header,omitempty"`
+	Sections []googleChatSection `json:"sections"`
+}
+
+type googleChatHeader struct {
+	Title     string `json:"title"`
+	Subtitle  string `json:"subtitle,omitempty"`
+	ImageURL  string `json:"imageUrl,omitempty"`
+	ImageType string `json:"imageType,omitempty"`
+}
+
+type googleChatSection struct {
+	Header  string           `json:"header,omitempty"`
+	Widgets []map[string]any `json:"widgets"`
+}
+
+// googleChatErrorEnvelope Google API 的錯誤格式
+type googleChatErrorEnvelope struct {
+	Error struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Status  string `json:"status"`
+	} `json:"error"`
+}
+
+// Encode 將訊息編碼為 Google Chat 格式
+func (p GoogleChatProvider) Encode(webhookURL string, msg Message) ([]byte, error) {
+	payload := googleChatMessage(msg)
+	if p.ThreadKey != "" {
+		payload.Thread = &googleChatThread{ThreadKey: p.ThreadKey}
+	}
+	data, err := sonic.Marshal(payload)
+	if err != nil {
+		return nil, NewSerializationError(err)
+	}
+	return data, nil
+}
+
+// ModifyRequest 設置 ThreadKey 時加入 messageReplyOption 查詢參數
+func (p GoogleChatProvider) ModifyRequest(req *http.Request) error {
+	if p.ThreadKey == "" {
+		return nil
+	}
+	option := p.ReplyOption
+	if option == "" {
+		option = GoogleChatReplyFallbackToNewThread
+	}
+	query := req.URL.Query()
+	query.Set("messageReplyOption", option)
+	req.URL.RawQuery = query.Encode()
+	return nil
+}
+
+// CheckResponse 解析 Google API 的錯誤格式
+func (GoogleChatProvider) CheckResponse(webhookURL string, statusCode int, body []byte) error {
+	if statusCode == http.StatusOK {
+		return nil
+	}
+	var envelope googleChatErrorEnvelope
+	if err := sonic.Unmarshal(body, &envelope); err != nil || envelope.Error.Status == "" {
+		return NewAPIError(webhookURL, statusCode, string(body))
+	}
+	return NewProviderError(webhookURL, statusCode, string(body), envelope.Error.Status, envelope.Error.Message)
+}
+
+// googleChatMessage 將訊息轉換為 Google Chat 格式（每個 attachment 對應一張卡片）
+func googleChatMessage(msg Message) googleChatPayload {
+	payload := googleChatPayload{Text: msg.Text}
+	for i, a := range msg.Attachments {
+		payload.CardsV2 = append(payload.CardsV2, googleChatCardV2{
+			CardID: fmt.Sprintf("attachment-%d", i),
+			Card:   googleChatCardFrom(a),
+		})
+	}
+	return payload
+}
+
+// googleChatCardFrom 將 attachment 轉換為卡片
+func googleChatCardFrom(a Attachment) googleChatCard {
+	var card googleChatCard
+
+	title, subtitle := a.Title, a.AuthorName
+	if title == "" {
+		title, subtitle = a.AuthorName, ""
+	}
+	if title != "" {
+		card.Header = &googleChatHeader{Title: title, Subtitle: subtitle}
+		if icon := firstNonEmpty(a.AuthorIcon, a.ThumbURL); icon != "" {
+			card.Header.ImageURL = icon
+			card.Header.ImageType = "CIRCLE"
+		}
+	}
+
+	var widgets []map[string]any
+	if a.Severity.Valid() {
+		widgets = append(widgets, map[string]any{"decoratedText": map[string]any{
+			"startIcon": map[string]any{"materialIcon": map[string]string{"name": a.Severity.GoogleChatIcon()}},
+			"text":      "<b>" + a.Severity.Label() + "</b>",
+		}})
+	}
+	if a.Text != "" {
+		widgets = append(widgets, map[string]any{"textParagraph": map[string]string{"text": a.Text}})
+	}
+	for _, f := range a.Fields {
+		widgets = append(widgets, map[string]any{"decoratedText": map[string]any{
+			"topLabel": f.Title,
+			"text":     f.Value,
+			"wrapText": !f.Short,
+		}})
+	}
+	if a.ImageURL != "" {
+		widgets = append(widgets, map[string]any{"image": map[string]string{
+			"imageUrl": a.ImageURL,
+			"altText":  firstNonEmpty(a.Title, a.Fallback),
+		}})
+	}
+	if a.Footer != "" {
+		widgets = append(widgets, map[string]any{"textParagraph": map[string]string{"text": a.Footer}})
+	}
+	if a.TitleLink != "" {
+		widgets = append(widgets, map[string]any{"buttonList": map[string]any{
+			"buttons": []map[string]any{{
+				"text":    firstNonEmpty(a.Title, "Open"),
+				"onClick": map[string]any{"openLink": map[string]string{"url": a.TitleLink}},
+			}},
+		}})
+	}
+	if len(widgets) == 0 {
+		widgets = append(widgets, map[string]any{"textParagraph": map[string]string{"text": a.Fallback}})
+	}
+
+	card.Sections = []googleChatSection{{Header: a.Pretext, Widgets: widgets}}
+	return card
+}
+
+// firstNonEmpty 返回第一個非空字串
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
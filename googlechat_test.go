@@ -0,0 +1,84 @@
+package samhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/bytedance/sonic"
+)
+
+func TestGoogleChatProvider_Encode(t *testing.T) {
+	msg := Message{
+		Text: "deploy finished",
+		Attachments: []Attachment{{
+			AuthorName: "ci",
+			Title:      "api",
+			TitleLink:  "https://example.com/runs/1",
+			Text:       "all green",
+			Fields:     []Field{{Title: "Region", Value: "us-east-1", Short: true}},
+			ImageURL:   "https://example.com/graph.png",
+		}},
+	}
+
+	data, err := GoogleChatProvider{ThreadKey: "deploy-1"}.Encode("", msg)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	var payload googleChatPayload
+	if err := sonic.Unmarshal(data, &payload); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if payload.Text != "deploy finished" || payload.Thread == nil || payload.Thread.ThreadKey != "deploy-1" {
+		t.Errorf("unexpected payload: %s", data)
+	}
+	if len(payload.CardsV2) != 1 {
+		t.Fatalf("expected 1 card, got %d", len(payload.CardsV2))
+	}
+	card := payload.CardsV2[0].Card
+	if card.Header == nil || card.Header.Title != "api" || card.Header.Subtitle != "ci" {
+		t.Errorf("unexpected header: %+v", card.Header)
+	}
+
+	var kinds []string
+	for _, w := range card.Sections[0].Widgets {
+		for k := range w {
+			kinds = append(kinds, k)
+		}
+	}
+	want := []string{"textParagraph", "decoratedText", "image", "buttonList"}
+	if len(kinds) != len(want) {
+		t.Fatalf("widgets = %v, want %v", kinds, want)
+	}
+	for i := range want {
+		if kinds[i] != want[i] {
+			t.Errorf("widget %d = %s, want %s", i, kinds[i], want[i])
+		}
+	}
+}
+
+func TestGoogleChatProvider_Send(t *testing.T) {
+	var replyOption string
+	server := mockWebhookServer(t, func(w http.ResponseWriter, r *http.Request) {
+		replyOption = r.URL.Query().Get("messageReplyOption")
+		if r.URL.Query().Get("token") != "t" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":{"code":400,"message":"Invalid token","status":"INVALID_ARGUMENT"}}`))
+		}
+	})
+
+	provider := GoogleChatProvider{ThreadKey: "deploy-1"}
+	if err := SendWithProvider(context.Background(), provider, server.URL+"?token=t", createTestMessage()); err != nil {
+		t.Fatalf("SendWithProvider() error = %v", err)
+	}
+	if replyOption != GoogleChatReplyFallbackToNewThread {
+		t.Errorf("messageReplyOption = %q", replyOption)
+	}
+
+	err := SendWithProvider(context.Background(), provider, server.URL+"?token=bad", createTestMessage())
+	var webhookErr *WebhookError
+	if !errors.As(err, &webhookErr) || webhookErr.ProviderCode != "INVALID_ARGUMENT" || webhookErr.StatusCode != 400 {
+		t.Errorf("expected parsed Google error, got %v", err)
+	}
+}
@@ -0,0 +1,377 @@
+// Package inbound 接收 Slack 與 Mattermost 的 slash command、互動訊息與 outgoing webhook
+//
+// Handler 驗證請求來源（Slack 的簽章或 token），解析為 Command、Interaction
+// 或 OutgoingWebhook，依指令名稱、action_id 或觸發詞分派給註冊的 handler，
+// handler 返回的 samhook.Message 作為同步回應。
+package inbound
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/circleyu/samhook"
+)
+
+// ResponseComment Mattermost outgoing webhook 以討論串回覆觸發的貼文
+const ResponseComment = "comment"
+
+// DefaultMaxSkew 預設允許的簽章時間誤差
+const DefaultMaxSkew = 5 * time.Minute
+
+// maxBodySize 請求體大小上限
+const maxBodySize = 1 << 20
+
+// 驗證失敗的錯誤
+var (
+	ErrInvalidSignature = errors.New("invalid request signature")
+	ErrStaleRequest     = errors.New("request timestamp outside allowed skew")
+	ErrInvalidToken     = errors.New("invalid verification token")
+)
+
+// Options 接收器選項（至少需要設置 SigningSecret 或 Tokens 其中之一）
+type Options struct {
+	// SigningSecret Slack app 的 signing secret，用於驗證 X-Slack-Signature
+	SigningSecret string
+
+	// Tokens Mattermost slash command 與 outgoing webhook（Slack 與 Mattermost）的 token
+	Tokens []string
+
+	// MaxSkew 允許的 X-Slack-Request-Timestamp 誤差，<= 0 時使用 DefaultMaxSkew
+	MaxSkew time.Duration
+}
+
+// CommandHandler 處理 slash command，返回的訊息作為同步回應（nil 時不回應內容）
+type CommandHandler func(ctx context.Context, cmd *Command) (*samhook.Message, error)
+
+// TriggerHandler 處理 outgoing webhook，返回的訊息作為回覆（nil 時不回覆）
+type TriggerHandler func(ctx context.Context, hook *OutgoingWebhook) (*samhook.Message, error)
+
+// ActionHandler 處理互動元件的操作，返回的訊息作為同步回應（nil 時不回應內容）
+type ActionHandler func(ctx context.Context, in *Interaction, action Action) (*samhook.Message, error)
+
+// Handler 接收 slash command 與互動訊息的 http.Handler，可安全地在多個 goroutine 中使用
+type Handler struct {
+	opts Options
+
+	mu       sync.RWMutex
+	commands map[string]CommandHandler
+	actions  map[string]ActionHandler
+	triggers map[string]TriggerHandler
+}
+
+// NewHandler 創建接收器
+func NewHandler(opts *Options) *Handler {
+	h := &Handler{
+		commands: make(map[string]CommandHandler),
+		actions:  make(map[string]ActionHandler),
+		triggers: make(map[string]TriggerHandler),
+	}
+	if opts != nil {
+		h.opts = *opts
+	}
+	if h.opts.MaxSkew <= 0 {
+		h.opts.MaxSkew = DefaultMaxSkew
+	}
+	return h
+}
+
+// HandleCommand 註冊 slash command 的 handler（例如 "/deploy"）
+func (h *Handler) HandleCommand(command string, fn CommandHandler) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.commands["/"+strings.TrimPrefix(command, "/")] = fn
+}
+
+// HandleAction 註冊互動元件的 handler，依 action_id 分派
+func (h *Handler) HandleAction(actionID string, fn ActionHandler) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.actions[actionID] = fn
+}
+
+// HandleTrigger 註冊 outgoing webhook 觸發詞的 handler（不分大小寫）
+//
+// word 為空字串時處理沒有對應觸發詞的請求（例如 Mattermost 依頻道觸發的 webhook）。
+func (h *Handler) HandleTrigger(word string, fn TriggerHandler) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.triggers[strings.ToLower(word)] = fn
+}
+
+// ServeHTTP 驗證、解析並分派請求
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+
+	// 只有通過簽章驗證的請求視為來自 Slack，其餘請求一律需要有效的 token
+	verified := false
+	if r.Header.Get("X-Slack-Signature") != "" {
+		if err := h.VerifySlack(r.Header, body); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		verified = true
+	}
+	platform := PlatformMattermost
+	if verified {
+		platform = PlatformSlack
+	}
+
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		if hook, ok := parseOutgoingJSON(body); ok {
+			h.serveOutgoing(w, r, hook)
+			return
+		}
+		in, err := parseMattermostInteraction(body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		h.serveInteraction(w, r, in, verified)
+		return
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		http.Error(w, "invalid form body", http.StatusBadRequest)
+		return
+	}
+	if payload := form.Get("payload"); payload != "" {
+		// Slack 的互動訊息一定帶有簽章
+		if !verified {
+			http.Error(w, ErrInvalidSignature.Error(), http.StatusUnauthorized)
+			return
+		}
+		in, err := parseSlackInteraction(payload)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		h.serveInteraction(w, r, in, verified)
+		return
+	}
+	if form.Get("command") != "" {
+		cmd := parseCommand(platform, form)
+		if !verified {
+			cmd.Token = firstNonEmpty(cmd.Token, strings.TrimPrefix(r.Header.Get("Authorization"), "Token "))
+		}
+		h.serveCommand(w, r, cmd, verified)
+		return
+	}
+	if form.Has("trigger_word") || form.Has("token") {
+		h.serveOutgoing(w, r, parseOutgoingForm(form))
+		return
+	}
+	http.Error(w, "unrecognized request", http.StatusBadRequest)
+}
+
+// serveOutgoing 驗證 token 並呼叫觸發詞的 handler
+func (h *Handler) serveOutgoing(w http.ResponseWriter, r *http.Request, hook *OutgoingWebhook) {
+	if !h.validToken(hook.Token) {
+		http.Error(w, ErrInvalidToken.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	h.mu.RLock()
+	fn, ok := h.triggers[strings.ToLower(hook.TriggerWord)]
+	if !ok {
+		fn, ok = h.triggers[""]
+	}
+	h.mu.RUnlock()
+	if !ok {
+		// 沒有對應的 handler 時不回覆，避免在頻道中產生錯誤訊息
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	msg, err := fn(r.Context(), hook)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeResponse(w, msg, hook.Response)
+}
+
+// serveCommand 未通過簽章驗證時驗證 token，並呼叫指令的 handler
+func (h *Handler) serveCommand(w http.ResponseWriter, r *http.Request, cmd *Command, verified bool) {
+	if !verified && !h.validToken(cmd.Token) {
+		http.Error(w, ErrInvalidToken.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	h.mu.RLock()
+	fn, ok := h.commands[cmd.Command]
+	h.mu.RUnlock()
+	if !ok {
+		http.Error(w, fmt.Sprintf("unknown command %q", cmd.Command), http.StatusNotFound)
+		return
+	}
+
+	msg, err := fn(r.Context(), cmd)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeResponse(w, msg, cmd.Response)
+}
+
+// serveInteraction 未通過簽章驗證時驗證 token，並呼叫第一個有註冊 handler 的操作
+func (h *Handler) serveInteraction(w http.ResponseWriter, r *http.Request, in *Interaction, verified bool) {
+	if !verified && !h.validToken(in.Token) {
+		http.Error(w, ErrInvalidToken.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	var (
+		fn     ActionHandler
+		action Action
+	)
+	h.mu.RLock()
+	for _, a := range in.Actions {
+		if f, ok := h.actions[a.ActionID]; ok {
+			fn, action = f, a
+			break
+		}
+	}
+	h.mu.RUnlock()
+	if fn == nil {
+		http.Error(w, "no handler for action", http.StatusNotFound)
+		return
+	}
+
+	msg, err := fn(r.Context(), in, action)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if in.Platform == PlatformMattermost {
+		writeMattermostActionResponse(w, msg, in.Response)
+		return
+	}
+	writeResponse(w, msg, in.Response)
+}
+
+// VerifySlack 驗證 Slack 的 v0 簽章與時間戳記
+func (h *Handler) VerifySlack(header http.Header, body []byte) error {
+	if h.opts.SigningSecret == "" {
+		return ErrInvalidSignature
+	}
+	timestamp := header.Get("X-Slack-Request-Timestamp")
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrStaleRequest
+	}
+	if math.Abs(time.Since(time.Unix(ts, 0)).Seconds()) > h.opts.MaxSkew.Seconds() {
+		return ErrStaleRequest
+	}
+
+	signature, ok := strings.CutPrefix(header.Get("X-Slack-Signature"), "v0=")
+	if !ok {
+		return ErrInvalidSignature
+	}
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if !hmac.Equal(got, SlackSignature(h.opts.SigningSecret, timestamp, body)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// SlackSignature 計算 Slack 的 v0 簽章（HMAC-SHA256 of "v0:timestamp:body"）
+func SlackSignature(secret, timestamp string, body []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("v0:" + timestamp + ":"))
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+// validToken 以固定時間比較 token
+func (h *Handler) validToken(token string) bool {
+	if token == "" {
+		return false
+	}
+	for _, t := range h.opts.Tokens {
+		if subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// response 同步回應的內容
+type response struct {
+	samhook.Message
+	samhook.ResponseOptions
+}
+
+// writeResponse 將訊息寫為 JSON 回應，msg 為 nil 時返回空的 200
+func writeResponse(w http.ResponseWriter, msg *samhook.Message, opts samhook.ResponseOptions) {
+	if msg == nil {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	writeJSON(w, response{Message: *msg, ResponseOptions: opts})
+}
+
+// mattermostActionResponse Mattermost 互動訊息的回應格式
+type mattermostActionResponse struct {
+	Update        *mattermostUpdate `json:"update,omitempty"`
+	EphemeralText string            `json:"ephemeral_text,omitempty"`
+}
+
+type mattermostUpdate struct {
+	Message string         `json:"message"`
+	Props   map[string]any `json:"props,omitempty"`
+}
+
+// writeMattermostActionResponse ReplaceOriginal 時更新原貼文，否則以臨時訊息回覆文字
+func writeMattermostActionResponse(w http.ResponseWriter, msg *samhook.Message, opts samhook.ResponseOptions) {
+	if msg == nil {
+		writeJSON(w, mattermostActionResponse{})
+		return
+	}
+	if !opts.ReplaceOriginal {
+		writeJSON(w, mattermostActionResponse{EphemeralText: msg.Text})
+		return
+	}
+	update := &mattermostUpdate{Message: msg.Text, Props: map[string]any{}}
+	if len(msg.Attachments) > 0 {
+		update.Props["attachments"] = msg.Attachments
+	}
+	writeJSON(w, mattermostActionResponse{Update: update})
+}
+
+// writeJSON 寫入 JSON 回應
+func writeJSON(w http.ResponseWriter, v any) {
+	data, err := sonic.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
@@ -0,0 +1,231 @@
+package inbound
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/circleyu/samhook"
+)
+
+const testSecret = "8f742231b10e8888abcd99yyyzzz85a5"
+
+// signedRequest 建立帶有 Slack 簽章的表單請求
+func signedRequest(body string, ts time.Time) *http.Request {
+	timestamp := strconv.FormatInt(ts.Unix(), 10)
+	req := httptest.NewRequest(http.MethodPost, "/slack", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Slack-Request-Timestamp", timestamp)
+	req.Header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(SlackSignature(testSecret, timestamp, []byte(body))))
+	return req
+}
+
+func newTestHandler() *Handler {
+	h := NewHandler(&Options{SigningSecret: testSecret, Tokens: []string{"mm-token"}})
+	h.HandleCommand("deploy", func(ctx context.Context, cmd *Command) (*samhook.Message, error) {
+		cmd.Response.ResponseType = samhook.ResponseInChannel
+		return &samhook.Message{Text: cmd.Platform + " deploying " + cmd.Text}, nil
+	})
+	h.HandleAction("approve", func(ctx context.Context, in *Interaction, action Action) (*samhook.Message, error) {
+		in.Response.ReplaceOriginal = true
+		return &samhook.Message{Text: "approved " + action.Value + " by " + in.UserName}, nil
+	})
+	h.HandleAction("fail", func(ctx context.Context, in *Interaction, action Action) (*samhook.Message, error) {
+		return nil, errors.New("boom")
+	})
+	return h
+}
+
+func TestHandler_SlackCommand(t *testing.T) {
+	form := url.Values{"command": {"/deploy"}, "text": {"api"}, "user_name": {"alice"}, "response_url": {"https://hooks.slack.com/commands/1"}}
+	rec := httptest.NewRecorder()
+	newTestHandler().ServeHTTP(rec, signedRequest(form.Encode(), time.Now()))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body)
+	}
+	var resp map[string]any
+	sonic.Unmarshal(rec.Body.Bytes(), &resp)
+	if resp["text"] != "slack deploying api" || resp["response_type"] != samhook.ResponseInChannel {
+		t.Errorf("unexpected response: %s", rec.Body)
+	}
+}
+
+func TestHandler_SlackInteraction(t *testing.T) {
+	payload := `{"type":"block_actions","user":{"id":"U1","username":"alice"},"channel":{"id":"C1"},` +
+		`"container":{"message_ts":"1.2"},"response_url":"https://hooks.slack.com/actions/1",` +
+		`"actions":[{"action_id":"approve","block_id":"b","type":"button","value":"v42"}]}`
+	body := url.Values{"payload": {payload}}.Encode()
+	rec := httptest.NewRecorder()
+	newTestHandler().ServeHTTP(rec, signedRequest(body, time.Now()))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body)
+	}
+	var resp map[string]any
+	sonic.Unmarshal(rec.Body.Bytes(), &resp)
+	if resp["text"] != "approved v42 by alice" || resp["replace_original"] != true {
+		t.Errorf("unexpected response: %s", rec.Body)
+	}
+}
+
+func TestHandler_Mattermost(t *testing.T) {
+	h := newTestHandler()
+
+	form := url.Values{"command": {"/deploy"}, "text": {"web"}, "token": {"mm-token"}}
+	req := httptest.NewRequest(http.MethodPost, "/mm", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "mattermost deploying web") {
+		t.Errorf("command: status = %d: %s", rec.Code, rec.Body)
+	}
+
+	action := `{"user_id":"u1","user_name":"bob","post_id":"p1","context":{"action_id":"approve","token":"mm-token","selected_option":"v1"}}`
+	req = httptest.NewRequest(http.MethodPost, "/mm", strings.NewReader(action))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	var resp mattermostActionResponse
+	sonic.Unmarshal(rec.Body.Bytes(), &resp)
+	if rec.Code != http.StatusOK || resp.Update == nil || resp.Update.Message != "approved v1 by bob" {
+		t.Errorf("action: status = %d: %s", rec.Code, rec.Body)
+	}
+}
+
+func TestHandler_Rejects(t *testing.T) {
+	command := url.Values{"command": {"/deploy"}}.Encode()
+
+	tampered := signedRequest(command, time.Now())
+	tampered.Body = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(command+"&text=x")).Body
+
+	badToken := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(command+"&token=wrong"))
+	badToken.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	unknown := signedRequest(url.Values{"command": {"/unknown"}}.Encode(), time.Now())
+
+	unsignedAction := httptest.NewRequest(http.MethodPost, "/",
+		strings.NewReader(url.Values{"payload": {`{"token":"mm-token","actions":[{"action_id":"approve"}]}`}}.Encode()))
+	unsignedAction.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	failing := signedRequest(url.Values{"payload": {`{"actions":[{"action_id":"fail"}]}`}}.Encode(), time.Now())
+
+	tests := []struct {
+		name string
+		req  *http.Request
+		want int
+	}{
+		{name: "內容被竄改", req: tampered, want: http.StatusUnauthorized},
+		{name: "時間戳記過舊", req: signedRequest(command, time.Now().Add(-10*time.Minute)), want: http.StatusUnauthorized},
+		{name: "錯誤的 token", req: badToken, want: http.StatusUnauthorized},
+		{name: "未簽章的互動訊息", req: unsignedAction, want: http.StatusUnauthorized},
+		{name: "未註冊的指令", req: unknown, want: http.StatusNotFound},
+		{name: "handler 返回錯誤", req: failing, want: http.StatusInternalServerError},
+		{name: "不支援的方法", req: httptest.NewRequest(http.MethodGet, "/", nil), want: http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			newTestHandler().ServeHTTP(rec, tt.req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+		})
+	}
+}
+
+func TestCommand_Responder(t *testing.T) {
+	cmd := parseCommand(PlatformSlack, url.Values{"response_url": {"https://hooks.slack.com/commands/1"}})
+	r := cmd.Responder()
+	if r.URL != cmd.ResponseURL || r.Expired() || r.Remaining() != samhook.ResponseURLMaxUses {
+		t.Errorf("unexpected responder: %+v", r)
+	}
+}
+
+func TestHandler_OutgoingWebhook(t *testing.T) {
+	h := NewHandler(&Options{Tokens: []string{"out-token"}})
+	h.HandleTrigger("!deploy", func(ctx context.Context, hook *OutgoingWebhook) (*samhook.Message, error) {
+		if hook.Platform == PlatformMattermost {
+			hook.Response.ResponseType = ResponseComment
+		}
+		return &samhook.Message{Text: "deploying " + hook.Args()}, nil
+	})
+	h.HandleTrigger("", func(ctx context.Context, hook *OutgoingWebhook) (*samhook.Message, error) {
+		return nil, nil
+	})
+
+	mattermostJSON := `{"token":"out-token","post_id":"p1","timestamp":1700000000000,"text":"!deploy api","trigger_word":"!deploy","file_ids":"f1,f2"}`
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		wantStatus  int
+		wantText    string
+		wantType    string
+	}{
+		{
+			name:        "Slack 表單",
+			contentType: "application/x-www-form-urlencoded",
+			body:        url.Values{"token": {"out-token"}, "text": {"!Deploy web now"}, "trigger_word": {"!Deploy"}, "timestamp": {"1355517523.000005"}}.Encode(),
+			wantStatus:  http.StatusOK,
+			wantText:    "deploying web now",
+		},
+		{
+			name:        "Mattermost JSON",
+			contentType: "application/json",
+			body:        mattermostJSON,
+			wantStatus:  http.StatusOK,
+			wantText:    "deploying api",
+			wantType:    ResponseComment,
+		},
+		{
+			name:        "沒有對應的觸發詞",
+			contentType: "application/x-www-form-urlencoded",
+			body:        url.Values{"token": {"out-token"}, "text": {"hello"}}.Encode(),
+			wantStatus:  http.StatusOK,
+		},
+		{
+			name:        "錯誤的 token",
+			contentType: "application/x-www-form-urlencoded",
+			body:        url.Values{"token": {"wrong"}, "text": {"!deploy"}, "trigger_word": {"!deploy"}}.Encode(),
+			wantStatus:  http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/outgoing", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantText == "" {
+				if rec.Code == http.StatusOK && rec.Body.Len() != 0 {
+					t.Errorf("expected empty response, got %s", rec.Body)
+				}
+				return
+			}
+			var resp map[string]any
+			sonic.Unmarshal(rec.Body.Bytes(), &resp)
+			if resp["text"] != tt.wantText || (tt.wantType != "" && resp["response_type"] != tt.wantType) {
+				t.Errorf("unexpected response: %s", rec.Body)
+			}
+		})
+	}
+
+	hook, ok := parseOutgoingJSON([]byte(mattermostJSON))
+	if !ok || hook.Timestamp != "1700000000000" || len(hook.FileIDs) != 2 {
+		t.Errorf("unexpected parsed webhook: %+v", hook)
+	}
+}
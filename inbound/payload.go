@@ -0,0 +1,314 @@
+package inbound
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/circleyu/samhook"
+)
+
+// 請求來源的平台
+const (
+	PlatformSlack      = "slack"
+	PlatformMattermost = "mattermost"
+)
+
+// Command slash command 的請求內容（Slack 與 Mattermost 的欄位相同）
+type Command struct {
+	Platform    string
+	Token       string
+	TeamID      string
+	TeamDomain  string
+	ChannelID   string
+	ChannelName string
+	UserID      string
+	UserName    string
+	Command     string
+	Text        string
+	ResponseURL string
+	TriggerID   string
+
+	// Response 同步回應的選項，handler 可設置 ResponseType 為 samhook.ResponseInChannel
+	Response samhook.ResponseOptions
+
+	receivedAt time.Time
+}
+
+// Responder 返回此請求的 response_url，用於非同步的後續回覆
+func (c *Command) Responder() *samhook.ResponseURL {
+	return &samhook.ResponseURL{URL: c.ResponseURL, IssuedAt: c.receivedAt}
+}
+
+// Action 互動元件（按鈕、選單）的操作
+type Action struct {
+	// ActionID Block Kit 的 action_id，舊版 attachment 按鈕為 name
+	ActionID string
+	BlockID  string
+	Name     string
+	Type     string
+	Value    string
+}
+
+// Interaction 互動訊息的請求內容
+type Interaction struct {
+	Platform    string
+	Type        string
+	Token       string
+	CallbackID  string
+	TriggerID   string
+	ResponseURL string
+	TeamID      string
+	TeamDomain  string
+	ChannelID   string
+	ChannelName string
+	UserID      string
+	UserName    string
+
+	// MessageTS 觸發互動的訊息（Slack 為 ts，Mattermost 為 post ID）
+	MessageTS string
+
+	Actions []Action
+
+	// Context Mattermost integration 的 context
+	Context map[string]any
+
+	// Response 同步回應的選項，handler 可設置 ReplaceOriginal 以更新原訊息
+	Response samhook.ResponseOptions
+
+	receivedAt time.Time
+}
+
+// Responder 返回此請求的 response_url，用於非同步的後續回覆
+func (i *Interaction) Responder() *samhook.ResponseURL {
+	return &samhook.ResponseURL{URL: i.ResponseURL, IssuedAt: i.receivedAt}
+}
+
+// parseCommand 解析 slash command 的表單內容
+func parseCommand(platform string, form url.Values) *Command {
+	return &Command{
+		Platform:    platform,
+		Token:       form.Get("token"),
+		TeamID:      form.Get("team_id"),
+		TeamDomain:  form.Get("team_domain"),
+		ChannelID:   form.Get("channel_id"),
+		ChannelName: form.Get("channel_name"),
+		UserID:      form.Get("user_id"),
+		UserName:    form.Get("user_name"),
+		Command:     form.Get("command"),
+		Text:        form.Get("text"),
+		ResponseURL: form.Get("response_url"),
+		TriggerID:   form.Get("trigger_id"),
+		receivedAt:  time.Now(),
+	}
+}
+
+// slackInteraction Slack 互動的 payload 表單欄位（JSON）
+type slackInteraction struct {
+	Type        string `json:"type"`
+	Token       string `json:"token"`
+	CallbackID  string `json:"callback_id"`
+	TriggerID   string `json:"trigger_id"`
+	ResponseURL string `json:"response_url"`
+	MessageTS   string `json:"message_ts"`
+	Team        struct {
+		ID     string `json:"id"`
+		Domain string `json:"domain"`
+	} `json:"team"`
+	Channel struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"channel"`
+	User struct {
+		ID       string `json:"id"`
+		Username string `json:"username"`
+		Name     string `json:"name"`
+	} `json:"user"`
+	Container struct {
+		MessageTS string `json:"message_ts"`
+	} `json:"container"`
+	Actions []struct {
+		ActionID       string `json:"action_id"`
+		BlockID        string `json:"block_id"`
+		Name           string `json:"name"`
+		Type           string `json:"type"`
+		Value          string `json:"value"`
+		SelectedOption *struct {
+			Value string `json:"value"`
+		} `json:"selected_option"`
+		SelectedOptions []struct {
+			Value string `json:"value"`
+		} `json:"selected_options"`
+	} `json:"actions"`
+}
+
+// parseSlackInteraction 解析 Slack 的 payload 表單欄位
+func parseSlackInteraction(payload string) (*Interaction, error) {
+	var raw slackInteraction
+	if err := sonic.UnmarshalString(payload, &raw); err != nil {
+		return nil, fmt.Errorf("invalid interaction payload: %w", err)
+	}
+
+	in := &Interaction{
+		Platform:    PlatformSlack,
+		Type:        raw.Type,
+		Token:       raw.Token,
+		CallbackID:  raw.CallbackID,
+		TriggerID:   raw.TriggerID,
+		ResponseURL: raw.ResponseURL,
+		TeamID:      raw.Team.ID,
+		TeamDomain:  raw.Team.Domain,
+		ChannelID:   raw.Channel.ID,
+		ChannelName: raw.Channel.Name,
+		UserID:      raw.User.ID,
+		UserName:    firstNonEmpty(raw.User.Username, raw.User.Name),
+		MessageTS:   firstNonEmpty(raw.Container.MessageTS, raw.MessageTS),
+		receivedAt:  time.Now(),
+	}
+	for _, a := range raw.Actions {
+		action := Action{ActionID: a.ActionID, BlockID: a.BlockID, Name: a.Name, Type: a.Type, Value: a.Value}
+		if action.ActionID == "" {
+			action.ActionID = a.Name
+		}
+		if a.SelectedOption != nil {
+			action.Value = a.SelectedOption.Value
+		} else if len(a.SelectedOptions) > 0 {
+			action.Value = a.SelectedOptions[0].Value
+		}
+		in.Actions = append(in.Actions, action)
+	}
+	return in, nil
+}
+
+// mattermostInteraction Mattermost 互動訊息的請求內容
+type mattermostInteraction struct {
+	UserID      string         `json:"user_id"`
+	UserName    string         `json:"user_name"`
+	ChannelID   string         `json:"channel_id"`
+	ChannelName string         `json:"channel_name"`
+	TeamID      string         `json:"team_id"`
+	TeamDomain  string         `json:"team_domain"`
+	PostID      string         `json:"post_id"`
+	TriggerID   string         `json:"trigger_id"`
+	Type        string         `json:"type"`
+	Context     map[string]any `json:"context"`
+}
+
+// parseMattermostInteraction 解析 Mattermost 的互動請求
+//
+// Mattermost 的請求不含 action ID，因此由 context 的 action_id 取得，
+// 選單的選取值在 context 的 selected_option。
+func parseMattermostInteraction(body []byte) (*Interaction, error) {
+	var raw mattermostInteraction
+	if err := sonic.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("invalid interaction payload: %w", err)
+	}
+
+	actionID, _ := raw.Context["action_id"].(string)
+	value, _ := raw.Context["selected_option"].(string)
+	token, _ := raw.Context["token"].(string)
+	return &Interaction{
+		Platform:    PlatformMattermost,
+		Type:        raw.Type,
+		Token:       token,
+		TriggerID:   raw.TriggerID,
+		TeamID:      raw.TeamID,
+		TeamDomain:  raw.TeamDomain,
+		ChannelID:   raw.ChannelID,
+		ChannelName: raw.ChannelName,
+		UserID:      raw.UserID,
+		UserName:    raw.UserName,
+		MessageTS:   raw.PostID,
+		Actions:     []Action{{ActionID: actionID, Type: raw.Type, Value: value}},
+		Context:     raw.Context,
+		receivedAt:  time.Now(),
+	}, nil
+}
+
+// OutgoingWebhook outgoing webhook 的請求內容（頻道中出現觸發詞時發送）
+type OutgoingWebhook struct {
+	Platform    string   `json:"-"`
+	Token       string   `json:"token"`
+	TeamID      string   `json:"team_id"`
+	TeamDomain  string   `json:"team_domain"`
+	ChannelID   string   `json:"channel_id"`
+	ChannelName string   `json:"channel_name"`
+	Timestamp   string   `json:"-"`
+	UserID      string   `json:"user_id"`
+	UserName    string   `json:"user_name"`
+	PostID      string   `json:"post_id"`
+	Text        string   `json:"text"`
+	TriggerWord string   `json:"trigger_word"`
+	FileIDs     []string `json:"-"`
+
+	// Response 同步回應的選項，Mattermost 可設置 ResponseType 為 ResponseComment 以回覆討論串
+	Response samhook.ResponseOptions `json:"-"`
+}
+
+// Args 返回觸發詞之後的文字
+func (o *OutgoingWebhook) Args() string {
+	text := strings.TrimSpace(o.Text)
+	if len(text) >= len(o.TriggerWord) && strings.EqualFold(text[:len(o.TriggerWord)], o.TriggerWord) {
+		text = text[len(o.TriggerWord):]
+	}
+	return strings.TrimSpace(text)
+}
+
+// parseOutgoingForm 解析 outgoing webhook 的表單內容
+func parseOutgoingForm(form url.Values) *OutgoingWebhook {
+	o := &OutgoingWebhook{
+		Platform:    PlatformSlack,
+		Token:       form.Get("token"),
+		TeamID:      form.Get("team_id"),
+		TeamDomain:  form.Get("team_domain"),
+		ChannelID:   form.Get("channel_id"),
+		ChannelName: form.Get("channel_name"),
+		Timestamp:   form.Get("timestamp"),
+		UserID:      form.Get("user_id"),
+		UserName:    form.Get("user_name"),
+		PostID:      form.Get("post_id"),
+		Text:        form.Get("text"),
+		TriggerWord: form.Get("trigger_word"),
+	}
+	if o.PostID != "" {
+		o.Platform = PlatformMattermost
+	}
+	if ids := form.Get("file_ids"); ids != "" {
+		o.FileIDs = strings.Split(ids, ",")
+	}
+	return o
+}
+
+// parseOutgoingJSON 解析 Mattermost 以 application/json 發送的 outgoing webhook
+//
+// 不含頂層 token 欄位時返回 false（互動訊息的請求）。
+func parseOutgoingJSON(body []byte) (*OutgoingWebhook, bool) {
+	var raw struct {
+		OutgoingWebhook
+		Timestamp json.Number `json:"timestamp"`
+		FileIDs   string      `json:"file_ids"`
+	}
+	if err := sonic.Unmarshal(body, &raw); err != nil || raw.Token == "" {
+		return nil, false
+	}
+	o := raw.OutgoingWebhook
+	o.Platform = PlatformMattermost
+	o.Timestamp = raw.Timestamp.String()
+	if raw.FileIDs != "" {
+		o.FileIDs = strings.Split(raw.FileIDs, ",")
+	}
+	return &o, true
+}
+
+// firstNonEmpty 返回第一個非空字串
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
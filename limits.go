@@ -0,0 +1,415 @@
+package samhook
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Limits 平台的訊息大小限制（0 表示不限制，長度預設以字元計算）
+type Limits struct {
+	MaxTextLength       int
+	MaxAttachments      int
+	MaxFields           int
+	MaxAttachmentText   int
+	MaxTitleLength      int
+	MaxFieldTitleLength int
+	MaxFieldValueLength int
+
+	// Bytes 長度以 UTF-8 位元組計算（例如企業微信），截斷與拆分也以位元組為單位
+	Bytes bool
+}
+
+// length 依 Bytes 返回字串的長度
+func (l Limits) length(s string) int {
+	if l.Bytes {
+		return len(s)
+	}
+	return utf8.RuneCountInString(s)
+}
+
+// truncate 依 Bytes 截斷字串
+func (l Limits) truncate(s string, limit int, marker string) string {
+	if l.Bytes {
+		return truncateBytes(s, limit, marker)
+	}
+	return truncateRunes(s, limit, marker)
+}
+
+// split 依 Bytes 拆分字串
+func (l Limits) split(s string, limit int) []string {
+	if l.Bytes {
+		return splitBytes(s, limit)
+	}
+	return splitText(s, limit)
+}
+
+// LimitStrategy 超出限制時的處理策略
+type LimitStrategy int
+
+const (
+	// LimitReject 返回驗證錯誤，不發送
+	LimitReject LimitStrategy = iota
+	// LimitTruncate 截斷超出的內容並加上截斷標記
+	LimitTruncate
+	// LimitSplit 拆分為多則依序發送的訊息
+	LimitSplit
+)
+
+// DefaultTruncateMarker 預設截斷標記
+const DefaultTruncateMarker = "…"
+
+// LimitOptions 限制檢查選項
+type LimitOptions struct {
+	// Provider 目標平台，為 nil 時使用 SlackProvider
+	Provider Provider
+	// Strategy 超出限制時的處理策略
+	Strategy LimitStrategy
+	// Marker 截斷標記，為空時使用 DefaultTruncateMarker
+	Marker string
+}
+
+// LimitViolation 單一超出限制的欄位
+type LimitViolation struct {
+	Field  string
+	Length int
+	Limit  int
+}
+
+// String 返回可讀的描述
+func (v LimitViolation) String() string {
+	return fmt.Sprintf("%s: %d exceeds limit %d", v.Field, v.Length, v.Limit)
+}
+
+// LimitError 訊息超出平台限制的詳細資訊
+type LimitError struct {
+	Provider   string
+	Violations []LimitViolation
+}
+
+// Error 實現 error 介面
+func (e *LimitError) Error() string {
+	parts := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		parts[i] = v.String()
+	}
+	return fmt.Sprintf("message exceeds %s limits: %s", e.Provider, strings.Join(parts, "; "))
+}
+
+// Check 檢查訊息是否超出限制，返回所有違規項目
+func (l Limits) Check(msg Message) []LimitViolation {
+	var violations []LimitViolation
+	check := func(field string, length, limit int) {
+		if limit > 0 && length > limit {
+			violations = append(violations, LimitViolation{Field: field, Length: length, Limit: limit})
+		}
+	}
+
+	check("text", l.length(msg.Text), l.MaxTextLength)
+	check("attachments", len(msg.Attachments), l.MaxAttachments)
+	for i, a := range msg.Attachments {
+		prefix := fmt.Sprintf("attachments[%d]", i)
+		check(prefix+".text", l.length(a.Text), l.MaxAttachmentText)
+		check(prefix+".pretext", l.length(a.Pretext), l.MaxAttachmentText)
+		check(prefix+".title", l.length(a.Title), l.MaxTitleLength)
+		check(prefix+".fields", len(a.Fields), l.MaxFields)
+		for j, f := range a.Fields {
+			fieldPrefix := fmt.Sprintf("%s.fields[%d]", prefix, j)
+			check(fieldPrefix+".title", l.length(f.Title), l.MaxFieldTitleLength)
+			check(fieldPrefix+".value", l.length(f.Value), l.MaxFieldValueLength)
+		}
+	}
+	return violations
+}
+
+// ApplyLimits 依照選項處理訊息，返回一則或多則符合限制的訊息
+func ApplyLimits(msg Message, opts LimitOptions) ([]Message, error) {
+	provider := opts.Provider
+	if provider == nil {
+		provider = SlackProvider{}
+	}
+	marker := opts.Marker
+	if marker == "" {
+		marker = DefaultTruncateMarker
+	}
+	limits := provider.Limits()
+
+	violations := limits.Check(msg)
+	if len(violations) == 0 {
+		return []Message{msg}, nil
+	}
+
+	switch opts.Strategy {
+	case LimitTruncate:
+		return []Message{truncateMessage(msg, limits, marker)}, nil
+	case LimitSplit:
+		return splitMessage(msg, limits), nil
+	default:
+		return nil, NewValidationError(ErrorCodePayloadTooLarge, &LimitError{
+			Provider:   provider.Name(),
+			Violations: violations,
+		})
+	}
+}
+
+// SendWithLimits 檢查平台限制後發送，拆分的訊息會依序發送
+//
+// opts.Provider 為 nil 時依 URL 偵測平台。
+func SendWithLimits(ctx context.Context, url string, msg Message, opts LimitOptions, clientOpts ...ClientOption) error {
+	opts.Provider = resolveProvider(url, opts.Provider)
+	messages, err := ApplyLimits(msg, opts)
+	if err != nil {
+		return err
+	}
+	client := newClient(clientOpts)
+	for _, m := range messages {
+		if err := sendMessage(ctx, client, url, opts.Provider, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// truncateMessage 截斷所有超出限制的內容
+func truncateMessage(msg Message, limits Limits, marker string) Message {
+	msg.Text = limits.truncate(msg.Text, limits.MaxTextLength, marker)
+	attachments := msg.Attachments
+	if limits.MaxAttachments > 0 && len(attachments) > limits.MaxAttachments {
+		attachments = attachments[:limits.MaxAttachments]
+	}
+	msg.Attachments = nil
+	for _, a := range attachments {
+		a = truncateAttachment(a, limits, marker)
+		if limits.MaxFields > 0 && len(a.Fields) > limits.MaxFields {
+			a.Fields = a.Fields[:limits.MaxFields]
+		}
+		msg.Attachments = append(msg.Attachments, a)
+	}
+	return msg
+}
+
+// truncateAttachment 截斷 attachment 中的文字欄位（不處理欄位數量）
+func truncateAttachment(a Attachment, limits Limits, marker string) Attachment {
+	a.Text = limits.truncate(a.Text, limits.MaxAttachmentText, marker)
+	a.Pretext = limits.truncate(a.Pretext, limits.MaxAttachmentText, marker)
+	a.Title = limits.truncate(a.Title, limits.MaxTitleLength, marker)
+	if len(a.Fields) > 0 {
+		fields := make([]Field, len(a.Fields))
+		for i, f := range a.Fields {
+			f.Title = limits.truncate(f.Title, limits.MaxFieldTitleLength, marker)
+			f.Value = limits.truncate(f.Value, limits.MaxFieldValueLength, marker)
+			fields[i] = f
+		}
+		a.Fields = fields
+	}
+	return a
+}
+
+// splitMessage 將訊息拆分為多則，保持原本順序：先文字，後 attachments
+func splitMessage(msg Message, limits Limits) []Message {
+	base := msg
+	base.Text = ""
+	base.Attachments = nil
+	base.Blocks = nil
+
+	var messages []Message
+	for _, chunk := range limits.split(msg.Text, limits.MaxTextLength) {
+		m := base
+		m.Text = chunk
+		messages = append(messages, m)
+	}
+
+	// 過長的文字與過多的欄位拆成多個接續的 attachment，不截斷任何內容
+	var attachments []Attachment
+	for _, a := range msg.Attachments {
+		attachments = append(attachments, splitAttachment(a, limits)...)
+	}
+
+	// 第一批 attachments 附在最後一則文字訊息上
+	batch := limits.MaxAttachments
+	if batch <= 0 {
+		batch = len(attachments)
+	}
+	for start := 0; start < len(attachments); start += batch {
+		end := min(start+batch, len(attachments))
+		if start == 0 && len(messages) > 0 {
+			messages[len(messages)-1].Attachments = attachments[start:end]
+			continue
+		}
+		m := base
+		m.Attachments = attachments[start:end]
+		messages = append(messages, m)
+	}
+
+	if len(messages) == 0 {
+		messages = append(messages, base)
+	}
+	// 區塊只附在第一則訊息，避免每則重複
+	messages[0].Blocks = msg.Blocks
+	return messages
+}
+
+// splitAttachment 將超出限制的 attachment 拆分為多個接續的 attachment
+//
+// pretext 與標題超出的部分移到內容的開頭，內容依 MaxAttachmentText 拆分；
+// 過長的欄位值拆成多個同名欄位，欄位依 MaxFields 分組，第一組附在最後一段
+// 內容上。
+func splitAttachment(a Attachment, limits Limits) []Attachment {
+	var overflow []string
+	pretext := limits.split(a.Pretext, limits.MaxAttachmentText)
+	if len(pretext) > 1 {
+		a.Pretext = pretext[0]
+		overflow = append(overflow, pretext[1:]...)
+	}
+	title := limits.split(a.Title, limits.MaxTitleLength)
+	if len(title) > 1 {
+		a.Title = title[0]
+		overflow = append(overflow, title[1:]...)
+	}
+	if a.Text != "" {
+		overflow = append(overflow, a.Text)
+	}
+	texts := limits.split(strings.Join(overflow, "\n"), limits.MaxAttachmentText)
+
+	var fields []Field
+	for _, f := range a.Fields {
+		titles := limits.split(f.Title, limits.MaxFieldTitleLength)
+		if len(titles) > 1 {
+			f.Title = titles[0]
+			f.Value = strings.Join(append(titles[1:], f.Value), "\n")
+		}
+		values := limits.split(f.Value, limits.MaxFieldValueLength)
+		if len(values) <= 1 {
+			fields = append(fields, f)
+			continue
+		}
+		for _, v := range values {
+			fields = append(fields, Field{Title: f.Title, Value: v, Short: f.Short})
+		}
+	}
+	var groups [][]Field
+	batch := limits.MaxFields
+	if batch <= 0 {
+		batch = len(fields)
+	}
+	for start := 0; start < len(fields); start += batch {
+		groups = append(groups, fields[start:min(start+batch, len(fields))])
+	}
+
+	first := a
+	first.Text, first.Fields = "", nil
+	attachments := []Attachment{first}
+	for i, text := range texts {
+		if i > 0 {
+			attachments = append(attachments, continuationAttachment(a))
+		}
+		attachments[len(attachments)-1].Text = text
+	}
+	for i, group := range groups {
+		if i > 0 {
+			attachments = append(attachments, continuationAttachment(a))
+		}
+		attachments[len(attachments)-1].Fields = group
+	}
+	return attachments
+}
+
+// continuationAttachment 建立接續的 attachment，沿用原本的 fallback 與顏色
+func continuationAttachment(a Attachment) Attachment {
+	return Attachment{Fallback: a.Fallback, Color: a.Color, Severity: a.Severity}
+}
+
+// truncateRunes 將字串截斷至 limit 個字元（包含標記）
+func truncateRunes(s string, limit int, marker string) string {
+	if limit <= 0 || utf8.RuneCountInString(s) <= limit {
+		return s
+	}
+	runes := []rune(s)
+	markerLen := utf8.RuneCountInString(marker)
+	if markerLen >= limit {
+		return string(runes[:limit])
+	}
+	return string(runes[:limit-markerLen]) + marker
+}
+
+// splitText 將文字拆分為不超過 limit 個字元的片段，優先在換行或空白處斷開
+func splitText(s string, limit int) []string {
+	if s == "" {
+		return nil
+	}
+	runes := []rune(s)
+	if limit <= 0 || len(runes) <= limit {
+		return []string{s}
+	}
+
+	var chunks []string
+	for len(runes) > limit {
+		cut := lastIndexRune(runes[:limit], '\n')
+		if cut <= 0 {
+			cut = lastIndexRune(runes[:limit], ' ')
+		}
+		if cut <= 0 {
+			cut = limit
+		}
+		chunks = append(chunks, string(runes[:cut]))
+		runes = runes[cut:]
+		// 去掉斷點處的分隔字元
+		if len(runes) > 0 && (runes[0] == '\n' || runes[0] == ' ') {
+			runes = runes[1:]
+		}
+	}
+	if len(runes) > 0 {
+		chunks = append(chunks, string(runes))
+	}
+	return chunks
+}
+
+// splitBytes 將文字拆分為不超過 limit 位元組的片段（不切斷 UTF-8 字元），優先在換行或空白處斷開
+func splitBytes(s string, limit int) []string {
+	if s == "" {
+		return nil
+	}
+	if limit <= 0 || len(s) <= limit {
+		return []string{s}
+	}
+
+	var chunks []string
+	for len(s) > limit {
+		end := limit
+		for end > 0 && !utf8.RuneStart(s[end]) {
+			end--
+		}
+		if end == 0 {
+			// limit 小於單一字元的長度時至少保留一個字元
+			_, end = utf8.DecodeRuneInString(s)
+		}
+		cut := strings.LastIndexByte(s[:end], '\n')
+		if cut <= 0 {
+			cut = strings.LastIndexByte(s[:end], ' ')
+		}
+		if cut <= 0 {
+			cut = end
+		}
+		chunks = append(chunks, s[:cut])
+		s = s[cut:]
+		// 去掉斷點處的分隔字元
+		if len(s) > 0 && (s[0] == '\n' || s[0] == ' ') {
+			s = s[1:]
+		}
+	}
+	if len(s) > 0 {
+		chunks = append(chunks, s)
+	}
+	return chunks
+}
+
+// lastIndexRune 返回 r 在 runes 中最後出現的位置，找不到時返回 -1
+func lastIndexRune(runes []rune, r rune) int {
+	for i := len(runes) - 1; i >= 0; i-- {
+		if runes[i] == r {
+			return i
+		}
+	}
+	return -1
+}
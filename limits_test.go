@@ -0,0 +1,317 @@
+package samhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestLimits_Check(t *testing.T) {
+	limits := Limits{MaxTextLength: 5, MaxAttachments: 1, MaxFields: 1}
+
+	msg := Message{
+		Text: "too long text",
+		Attachments: []Attachment{
+			{Fields: []Field{{Title: "a"}, {Title: "b"}}},
+			{Title: "second"},
+		},
+	}
+
+	violations := limits.Check(msg)
+	if len(violations) != 3 {
+		t.Fatalf("expected 3 violations, got %d: %v", len(violations), violations)
+	}
+	if violations[0].Field != "text" || violations[0].Length != 13 || violations[0].Limit != 5 {
+		t.Errorf("unexpected text violation: %v", violations[0])
+	}
+	if violations[1].Field != "attachments" {
+		t.Errorf("expected attachments violation, got %v", violations[1])
+	}
+	if violations[2].Field != "attachments[0].fields" {
+		t.Errorf("expected fields violation, got %v", violations[2])
+	}
+
+	if v := (Limits{}).Check(msg); len(v) != 0 {
+		t.Errorf("zero limits should not report violations, got %v", v)
+	}
+}
+
+func TestApplyLimits_WithinLimits(t *testing.T) {
+	msg := createTestMessage()
+	messages, err := ApplyLimits(msg, LimitOptions{})
+	if err != nil {
+		t.Fatalf("ApplyLimits() error = %v", err)
+	}
+	if len(messages) != 1 || messages[0].Text != msg.Text {
+		t.Errorf("expected message unchanged, got %v", messages)
+	}
+}
+
+func TestApplyLimits_Reject(t *testing.T) {
+	msg := Message{Text: strings.Repeat("a", 2001)}
+	_, err := ApplyLimits(msg, LimitOptions{Provider: DiscordProvider{}, Strategy: LimitReject})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	var webhookErr *WebhookError
+	if !errors.As(err, &webhookErr) {
+		t.Fatalf("expected *WebhookError, got %T", err)
+	}
+	if !webhookErr.IsValidationError() {
+		t.Errorf("expected validation error, got type %s", webhookErr.Type)
+	}
+	if webhookErr.GetErrorCode() != ErrorCodePayloadTooLarge {
+		t.Errorf("expected %s, got %s", ErrorCodePayloadTooLarge, webhookErr.GetErrorCode())
+	}
+
+	var limitErr *LimitError
+	if !errors.As(err, &limitErr) {
+		t.Fatalf("expected *LimitError in chain")
+	}
+	if limitErr.Provider != ProviderNameDiscord || len(limitErr.Violations) != 1 {
+		t.Errorf("unexpected limit error: %v", limitErr)
+	}
+}
+
+func TestApplyLimits_Truncate(t *testing.T) {
+	fields := make([]Field, 30)
+	for i := range fields {
+		fields[i] = Field{Title: "title", Value: strings.Repeat("v", 1500)}
+	}
+	msg := Message{
+		Text:        strings.Repeat("中", 2500),
+		Attachments: []Attachment{{Title: "t", Fields: fields}},
+	}
+
+	messages, err := ApplyLimits(msg, LimitOptions{Provider: DiscordProvider{}, Strategy: LimitTruncate})
+	if err != nil {
+		t.Fatalf("ApplyLimits() error = %v", err)
+	}
+	if len(messages) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(messages))
+	}
+
+	got := messages[0]
+	if n := utf8.RuneCountInString(got.Text); n != 2000 {
+		t.Errorf("expected text truncated to 2000 runes, got %d", n)
+	}
+	if !strings.HasSuffix(got.Text, DefaultTruncateMarker) {
+		t.Error("expected truncated text to end with marker")
+	}
+	if len(got.Attachments[0].Fields) != 25 {
+		t.Errorf("expected 25 fields, got %d", len(got.Attachments[0].Fields))
+	}
+	if n := utf8.RuneCountInString(got.Attachments[0].Fields[0].Value); n != 1024 {
+		t.Errorf("expected field value truncated to 1024 runes, got %d", n)
+	}
+	if len(msg.Attachments[0].Fields) != 30 || len(msg.Attachments[0].Fields[0].Value) != 1500 {
+		t.Error("original message should not be modified")
+	}
+	if v := (DiscordProvider{}).Limits().Check(got); len(v) != 0 {
+		t.Errorf("truncated message still violates limits: %v", v)
+	}
+}
+
+func TestApplyLimits_Split(t *testing.T) {
+	attachments := make([]Attachment, 12)
+	for i := range attachments {
+		attachments[i] = Attachment{Title: "a"}
+	}
+	attachments[0].Fields = make([]Field, 30)
+
+	msg := Message{
+		Username:    "bot",
+		Channel:     "#alerts",
+		Text:        strings.Repeat("line\n", 500),
+		Attachments: attachments,
+	}
+
+	messages, err := ApplyLimits(msg, LimitOptions{Provider: DiscordProvider{}, Strategy: LimitSplit})
+	if err != nil {
+		t.Fatalf("ApplyLimits() error = %v", err)
+	}
+
+	var text strings.Builder
+	totalAttachments := 0
+	for i, m := range messages {
+		if v := (DiscordProvider{}).Limits().Check(m); len(v) != 0 {
+			t.Errorf("message %d violates limits: %v", i, v)
+		}
+		if m.Username != "bot" || m.Channel != "#alerts" {
+			t.Errorf("message %d lost metadata: %+v", i, m)
+		}
+		if m.Text != "" {
+			if text.Len() > 0 {
+				text.WriteString("\n")
+			}
+			text.WriteString(m.Text)
+		}
+		totalAttachments += len(m.Attachments)
+	}
+
+	if got, want := text.String(), msg.Text; got != want {
+		t.Errorf("split text does not reassemble to original (got %d bytes, want %d)", len(got), len(want))
+	}
+	// 30 個欄位拆成 2 個 attachment，總共 13 個
+	if totalAttachments != 13 {
+		t.Errorf("expected 13 attachments across messages, got %d", totalAttachments)
+	}
+}
+
+func TestApplyLimits_SplitBlocks(t *testing.T) {
+	msg := Message{
+		Text:   strings.Repeat("a ", 30000),
+		Blocks: []Block{{"type": "divider"}},
+	}
+	messages, err := ApplyLimits(msg, LimitOptions{Provider: SlackProvider{}, Strategy: LimitSplit})
+	if err != nil {
+		t.Fatalf("ApplyLimits() error = %v", err)
+	}
+	if len(messages) < 2 {
+		t.Fatalf("expected message to be split, got %d", len(messages))
+	}
+	// 區塊只出現在第一則
+	for i, m := range messages {
+		want := 0
+		if i == 0 {
+			want = 1
+		}
+		if len(m.Blocks) != want {
+			t.Errorf("message %d has %d blocks, want %d", i, len(m.Blocks), want)
+		}
+	}
+}
+
+func TestSplitText(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		limit int
+		want  []string
+	}{
+		{"空字串", "", 5, nil},
+		{"未超出", "abc", 5, []string{"abc"}},
+		{"在空白處斷開", "hello world", 8, []string{"hello", "world"}},
+		{"在換行處斷開", "ab cd\nef", 7, []string{"ab cd", "ef"}},
+		{"強制斷開", "abcdefgh", 3, []string{"abc", "def", "gh"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := splitText(tt.input, tt.limit)
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") || len(got) != len(tt.want) {
+				t.Errorf("splitText(%q, %d) = %q, want %q", tt.input, tt.limit, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSendWithLimits_SplitSendsInOrder(t *testing.T) {
+	var received []string
+	server := mockWebhookServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var msg Message
+		if err := decodeTestMessage(r, &msg); err != nil {
+			t.Errorf("invalid JSON: %v", err)
+		}
+		received = append(received, msg.Text)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	msg := Message{Text: strings.Repeat("x", 1999) + " " + "tail"}
+	// Discord 的 Slack 相容端點
+	err := SendWithLimits(context.Background(), server.URL+"/slack", msg, LimitOptions{
+		Provider: DiscordProvider{},
+		Strategy: LimitSplit,
+	})
+	if err != nil {
+		t.Fatalf("SendWithLimits() error = %v", err)
+	}
+	if len(received) != 2 || received[1] != "tail" {
+		t.Errorf("unexpected messages received: %d", len(received))
+	}
+}
+
+func TestSplitBytes(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		limit int
+		want  []string
+	}{
+		{name: "不需拆分", input: "abc", limit: 10, want: []string{"abc"}},
+		{name: "空白處斷開", input: "ab cd ef", limit: 5, want: []string{"ab", "cd ef"}},
+		{name: "不切斷多位元組字元", input: "告警告警", limit: 7, want: []string{"告警", "告警"}},
+		{name: "上限小於單一字元", input: "告警", limit: 2, want: []string{"告", "警"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := splitBytes(tt.input, tt.limit)
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("splitBytes() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyLimits_SplitAttachmentText(t *testing.T) {
+	long := strings.Repeat("word ", 2000)
+	msg := Message{Attachments: []Attachment{{
+		Fallback: "report",
+		Color:    Danger,
+		Severity: SeverityError,
+		Title:    "report",
+		Text:     long,
+		Fields: []Field{
+			{Title: "log", Value: strings.Repeat("x", 2500)},
+			{Title: "host", Value: "web-1", Short: true},
+		},
+		Footer: "ci",
+	}}}
+
+	// Discord：描述 4096 字元、欄位值 1024 字元
+	messages, err := ApplyLimits(msg, LimitOptions{Provider: DiscordProvider{}, Strategy: LimitSplit})
+	if err != nil {
+		t.Fatalf("ApplyLimits() error = %v", err)
+	}
+
+	var text, logValue strings.Builder
+	var attachments []Attachment
+	for i, m := range messages {
+		if v := (DiscordProvider{}).Limits().Check(m); len(v) != 0 {
+			t.Errorf("message %d violates limits: %v", i, v)
+		}
+		attachments = append(attachments, m.Attachments...)
+	}
+	for _, a := range attachments {
+		if strings.Contains(a.Text, DefaultTruncateMarker) {
+			t.Fatalf("attachment text was truncated: %q", a.Text)
+		}
+		if a.Color != Danger || a.Fallback != "report" || a.Severity != SeverityError {
+			t.Errorf("continuation attachment lost style: %+v", a)
+		}
+		if a.Text != "" {
+			if text.Len() > 0 {
+				text.WriteString(" ")
+			}
+			text.WriteString(a.Text)
+		}
+		for _, f := range a.Fields {
+			if f.Title == "log" {
+				logValue.WriteString(f.Value)
+			}
+		}
+	}
+	if n := len(strings.Fields(text.String())); n != 2000 {
+		t.Errorf("expected all 2000 words to be kept, got %d", n)
+	}
+	if logValue.Len() != 2500 {
+		t.Errorf("expected field value to be kept in full, got %d characters", logValue.Len())
+	}
+	if len(attachments) < 3 {
+		t.Errorf("expected continuation attachments, got %d", len(attachments))
+	}
+}
@@ -0,0 +1,162 @@
+package samhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/bytedance/sonic"
+)
+
+// MattermostClient Mattermost REST API v4 客戶端（使用個人存取權杖或 bot token）
+//
+// 與 incoming webhook 不同，REST API 可以編輯與刪除貼文、以 root_id 回覆
+// 討論串、發送臨時訊息與加入表情回應。
+type MattermostClient struct {
+	// BaseURL Mattermost 伺服器位址（例如 https://chat.example.com）
+	BaseURL string
+
+	// Token 個人存取權杖或 bot 的存取權杖
+	Token string
+
+	// Retry 重試選項，為 nil 時不重試
+	Retry *RetryOptions
+
+	// RateLimiter 每次呼叫前等待的限流器，為 nil 時不限流
+	RateLimiter *RateLimiter
+
+	// ClientOptions HTTP 客戶端選項
+	ClientOptions []ClientOption
+}
+
+// MattermostPost Mattermost 的貼文
+type MattermostPost struct {
+	ID        string         `json:"id,omitempty"`
+	ChannelID string         `json:"channel_id"`
+	RootID    string         `json:"root_id,omitempty"`
+	UserID    string         `json:"user_id,omitempty"`
+	Message   string         `json:"message"`
+	Props     map[string]any `json:"props,omitempty"`
+	CreateAt  int64          `json:"create_at,omitempty"`
+}
+
+// mattermostAppError Mattermost 的 AppError 回應
+type mattermostAppError struct {
+	ID            string `json:"id"`
+	Message       string `json:"message"`
+	DetailedError string `json:"detailed_error"`
+	StatusCode    int    `json:"status_code"`
+}
+
+// mattermostAPI 判斷 REST API 回應的平台
+type mattermostAPI struct{ MattermostProvider }
+
+// CheckResponse 2xx 視為成功，否則解析 AppError（ProviderCode 為錯誤 ID）
+func (mattermostAPI) CheckResponse(webhookURL string, statusCode int, body []byte) error {
+	if statusCode >= 200 && statusCode < 300 {
+		return nil
+	}
+	var appErr mattermostAppError
+	if err := sonic.Unmarshal(body, &appErr); err != nil || appErr.ID == "" {
+		return NewAPIError(webhookURL, statusCode, string(body))
+	}
+	return NewProviderError(webhookURL, statusCode, string(body), appErr.ID, appErr.Message)
+}
+
+// mattermostPostFrom 將訊息轉換為貼文（attachment 放在 props 中）
+//
+// msg.ThreadTS 對應為 root_id；Username 與 IconURL 需要伺服器允許覆寫才會生效。
+func mattermostPostFrom(channelID string, msg Message) MattermostPost {
+	post := MattermostPost{ChannelID: channelID, RootID: msg.ThreadTS, Message: msg.Text}
+	props := map[string]any{}
+	if len(msg.Attachments) > 0 {
+		props["attachments"] = msg.Attachments
+	}
+	if msg.Username != "" {
+		props["override_username"] = msg.Username
+	}
+	if msg.IconURL != "" {
+		props["override_icon_url"] = msg.IconURL
+	}
+	if len(props) > 0 {
+		post.Props = props
+	}
+	return post
+}
+
+// CreatePost 在頻道中建立貼文，msg.ThreadTS 不為空時回覆該討論串
+func (c *MattermostClient) CreatePost(ctx context.Context, channelID string, msg Message) (MattermostPost, error) {
+	if channelID == "" {
+		return MattermostPost{}, NewValidationError(ErrorCodeInvalidMessage, errors.New("channel id is required"))
+	}
+	var post MattermostPost
+	err := c.call(ctx, http.MethodPost, "/posts", mattermostPostFrom(channelID, msg), &post)
+	return post, err
+}
+
+// Reply 在 parent 的討論串中回覆
+func (c *MattermostClient) Reply(ctx context.Context, parent MattermostPost, msg Message) (MattermostPost, error) {
+	msg.ThreadTS = firstNonEmpty(parent.RootID, parent.ID)
+	return c.CreatePost(ctx, parent.ChannelID, msg)
+}
+
+// PatchPost 將貼文的內容替換為 msg（文字與 attachment）
+func (c *MattermostClient) PatchPost(ctx context.Context, postID string, msg Message) (MattermostPost, error) {
+	patch := mattermostPostFrom("", msg)
+	payload := map[string]any{"message": patch.Message, "props": patch.Props}
+	if patch.Props == nil {
+		payload["props"] = map[string]any{}
+	}
+	var post MattermostPost
+	err := c.call(ctx, http.MethodPut, "/posts/"+url.PathEscape(postID)+"/patch", payload, &post)
+	return post, err
+}
+
+// DeletePost 刪除貼文
+func (c *MattermostClient) DeletePost(ctx context.Context, postID string) error {
+	return c.call(ctx, http.MethodDelete, "/posts/"+url.PathEscape(postID), nil, nil)
+}
+
+// CreateEphemeralPost 發送只有 userID 看得到的臨時訊息（不會保存）
+func (c *MattermostClient) CreateEphemeralPost(ctx context.Context, userID, channelID string, msg Message) (MattermostPost, error) {
+	if userID == "" || channelID == "" {
+		return MattermostPost{}, NewValidationError(ErrorCodeInvalidMessage, errors.New("user id and channel id are required"))
+	}
+	payload := map[string]any{"user_id": userID, "post": mattermostPostFrom(channelID, msg)}
+	var post MattermostPost
+	err := c.call(ctx, http.MethodPost, "/posts/ephemeral", payload, &post)
+	return post, err
+}
+
+// AddReaction 以 userID 的身分對貼文加入表情回應（emoji 名稱不含冒號）
+func (c *MattermostClient) AddReaction(ctx context.Context, userID, postID, emoji string) error {
+	payload := map[string]string{
+		"user_id":    userID,
+		"post_id":    postID,
+		"emoji_name": strings.Trim(emoji, ":"),
+	}
+	return c.call(ctx, http.MethodPost, "/reactions", payload, nil)
+}
+
+// RemoveReaction 移除 userID 對貼文的表情回應
+func (c *MattermostClient) RemoveReaction(ctx context.Context, userID, postID, emoji string) error {
+	path := "/users/" + url.PathEscape(userID) + "/posts/" + url.PathEscape(postID) + "/reactions/" + url.PathEscape(strings.Trim(emoji, ":"))
+	return c.call(ctx, http.MethodDelete, path, nil, nil)
+}
+
+// call 呼叫 /api/v4 底下的 API 並將回應解析到 result
+func (c *MattermostClient) call(ctx context.Context, method, path string, payload, result any) error {
+	if c.BaseURL == "" {
+		return NewValidationError(ErrorCodeInvalidMessage, errors.New("mattermost base URL is required"))
+	}
+	api := apiCall{
+		provider:      mattermostAPI{},
+		token:         c.Token,
+		retry:         c.Retry,
+		limiter:       c.RateLimiter,
+		clientOptions: c.ClientOptions,
+	}
+	return api.do(ctx, method, strings.TrimSuffix(c.BaseURL, "/")+"/api/v4"+path, payload, result)
+}
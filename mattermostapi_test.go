@@ -0,0 +1,105 @@
+package samhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/bytedance/sonic"
+)
+
+func TestMattermostClient_Posts(t *testing.T) {
+	type call struct {
+		method, path string
+		payload      map[string]any
+	}
+	var calls []call
+	server := mockWebhookServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer mm-token" {
+			t.Errorf("unexpected authorization: %q", r.Header.Get("Authorization"))
+		}
+		var payload map[string]any
+		sonic.ConfigDefault.NewDecoder(r.Body).Decode(&payload)
+		calls = append(calls, call{r.Method, r.URL.Path, payload})
+		if r.Method == http.MethodPost && r.URL.Path == "/api/v4/posts" {
+			w.WriteHeader(http.StatusCreated)
+		}
+		w.Write([]byte(`{"id":"p1","channel_id":"c1","message":"ok"}`))
+	})
+
+	client := &MattermostClient{BaseURL: server.URL + "/", Token: "mm-token"}
+	ctx := context.Background()
+	msg := Message{Text: "部署完成", Attachments: []Attachment{{Title: "api", Color: Good}}}
+
+	post, err := client.CreatePost(ctx, "c1", msg)
+	if err != nil || post.ID != "p1" {
+		t.Fatalf("CreatePost() = %+v, %v", post, err)
+	}
+	if _, err := client.Reply(ctx, post, Message{Text: "細節"}); err != nil {
+		t.Fatalf("Reply() error = %v", err)
+	}
+	if _, err := client.PatchPost(ctx, "p1", Message{Text: "已更新"}); err != nil {
+		t.Fatalf("PatchPost() error = %v", err)
+	}
+	if _, err := client.CreateEphemeralPost(ctx, "u1", "c1", Message{Text: "只有你看得到"}); err != nil {
+		t.Fatalf("CreateEphemeralPost() error = %v", err)
+	}
+	if err := client.AddReaction(ctx, "u1", "p1", ":white_check_mark:"); err != nil {
+		t.Fatalf("AddReaction() error = %v", err)
+	}
+	if err := client.DeletePost(ctx, "p1"); err != nil {
+		t.Fatalf("DeletePost() error = %v", err)
+	}
+
+	want := []struct{ method, path string }{
+		{http.MethodPost, "/api/v4/posts"},
+		{http.MethodPost, "/api/v4/posts"},
+		{http.MethodPut, "/api/v4/posts/p1/patch"},
+		{http.MethodPost, "/api/v4/posts/ephemeral"},
+		{http.MethodPost, "/api/v4/reactions"},
+		{http.MethodDelete, "/api/v4/posts/p1"},
+	}
+	if len(calls) != len(want) {
+		t.Fatalf("expected %d calls, got %d", len(want), len(calls))
+	}
+	for i, w := range want {
+		if calls[i].method != w.method || calls[i].path != w.path {
+			t.Errorf("call %d = %s %s, want %s %s", i, calls[i].method, calls[i].path, w.method, w.path)
+		}
+	}
+
+	props, _ := calls[0].payload["props"].(map[string]any)
+	if attachments, _ := props["attachments"].([]any); len(attachments) != 1 || calls[0].payload["channel_id"] != "c1" {
+		t.Errorf("unexpected create payload: %v", calls[0].payload)
+	}
+	if calls[1].payload["root_id"] != "p1" {
+		t.Errorf("expected reply to set root_id, got %v", calls[1].payload)
+	}
+	if calls[2].payload["message"] != "已更新" {
+		t.Errorf("unexpected patch payload: %v", calls[2].payload)
+	}
+	if calls[3].payload["user_id"] != "u1" {
+		t.Errorf("unexpected ephemeral payload: %v", calls[3].payload)
+	}
+	if calls[4].payload["emoji_name"] != "white_check_mark" {
+		t.Errorf("unexpected reaction payload: %v", calls[4].payload)
+	}
+}
+
+func TestMattermostClient_AppError(t *testing.T) {
+	server := mockWebhookServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"id":"api.context.permissions.app_error","message":"You do not have the appropriate permissions.","status_code":403}`))
+	})
+
+	client := &MattermostClient{BaseURL: server.URL, Token: "mm-token"}
+	_, err := client.CreatePost(context.Background(), "c1", createTestMessage())
+	var webhookErr *WebhookError
+	if !errors.As(err, &webhookErr) {
+		t.Fatalf("expected *WebhookError, got %v", err)
+	}
+	if webhookErr.ProviderCode != "api.context.permissions.app_error" || webhookErr.GetErrorCode() != ErrorCodeAPIForbidden {
+		t.Errorf("unexpected error: %+v", webhookErr)
+	}
+}
@@ -1,5 +1,10 @@
 package samhook
 
+import (
+	"strconv"
+	"strings"
+)
+
 // Warning is a predefined color for a warning (yellow)
 const Warning string = "#FFBB00"
 
@@ -18,8 +23,18 @@ type Message struct {
 	Channel     string       `json:"channel,omitempty"`
 	Text        string       `json:"text,omitempty"`
 	Attachments []Attachment `json:"attachments,omitempty"`
+
+	// Blocks Slack Block Kit 區塊（其他平台忽略）
+	Blocks []Block `json:"blocks,omitempty"`
+
+	// ThreadTS 回覆的討論串（父訊息的 ts），ReplyBroadcast 同時發送到頻道
+	ThreadTS       string `json:"thread_ts,omitempty"`
+	ReplyBroadcast bool   `json:"reply_broadcast,omitempty"`
 }
 
+// Block Slack Block Kit 的區塊（以 JSON 物件表示，例如 {"type":"divider"}）
+type Block map[string]any
+
 // Attachment attachment主體
 type Attachment struct {
 	Fallback   string  `json:"fallback,omitempty"`
@@ -36,6 +51,25 @@ type Attachment struct {
 	Footer     string  `json:"footer,omitempty"`
 	FooterIcon string  `json:"footer_icon,omitempty"`
 	ThumbURL   string  `json:"thumb_url,omitempty"`
+
+	// TS 顯示在 footer 旁的時間（Unix 秒）
+	TS int64 `json:"ts,omitempty"`
+
+	// MrkdwnIn 解析 mrkdwn 格式的欄位（例如 "text"、"pretext"、"fields"）
+	MrkdwnIn []string `json:"mrkdwn_in,omitempty"`
+
+	// CallbackID 互動時返回的識別碼（Slack 含 actions 時必須設置）
+	CallbackID string `json:"callback_id,omitempty"`
+
+	// AttachmentType 含 actions 時為 "default"
+	AttachmentType string `json:"attachment_type,omitempty"`
+
+	// Actions 按鈕與選單
+	Actions []Action `json:"actions,omitempty"`
+
+	// Severity 嚴重程度，設置時 Teams、Discord、Google Chat 與 Telegram 使用對應的樣式
+	// （Color 設為其他顏色時，顏色與標記以 Color 為準）
+	Severity Severity `json:"-"`
 }
 
 // Field field主體
@@ -44,3 +78,27 @@ type Field struct {
 	Value string `json:"value,omitempty"`
 	Short bool   `json:"short,omitempty"`
 }
+
+// colorValue 將具名或十六進位顏色轉換為 0xRRGGBB 整數
+func colorValue(color string) (int, bool) {
+	switch strings.ToLower(color) {
+	case "good":
+		color = Good
+	case "warning":
+		color = Warning
+	case "danger":
+		color = Danger
+	}
+	if !hexColorPattern.MatchString(color) {
+		return 0, false
+	}
+	hex := color[1:]
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	v, err := strconv.ParseInt(hex, 16, 32)
+	if err != nil {
+		return 0, false
+	}
+	return int(v), true
+}
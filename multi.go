@@ -0,0 +1,127 @@
+package samhook
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultMultiConcurrency SendMulti 預設的最大並行數
+const DefaultMultiConcurrency = 4
+
+// MultiOptions 多目標發送選項
+type MultiOptions struct {
+	// Concurrency 最大並行數，<= 0 時使用 DefaultMultiConcurrency
+	Concurrency int
+}
+
+// SendResult 單一目標的發送結果
+type SendResult struct {
+	Destination string
+	URL         string
+	Err         error
+	Duration    time.Duration
+}
+
+// DestinationError 單一目標的發送錯誤
+type DestinationError struct {
+	Destination string
+	Err         error
+}
+
+// Error 實現 error 介面
+func (e *DestinationError) Error() string {
+	return fmt.Sprintf("destination %q: %v", e.Destination, e.Err)
+}
+
+// Unwrap 返回原始錯誤（通常為 *WebhookError）
+func (e *DestinationError) Unwrap() error {
+	return e.Err
+}
+
+// MultiError 多目標發送中失敗目標的錯誤集合，支援 errors.Is 與 errors.As
+type MultiError struct {
+	Errors []*DestinationError
+	Total  int
+}
+
+// Error 實現 error 介面
+func (e *MultiError) Error() string {
+	parts := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		parts[i] = err.Error()
+	}
+	return fmt.Sprintf("%d of %d destinations failed: %s", len(e.Errors), e.Total, strings.Join(parts, "; "))
+}
+
+// Unwrap 返回所有目標的錯誤
+func (e *MultiError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
+}
+
+// SendMulti 以預設並行數將同一則訊息發送到多個目標
+func SendMulti(ctx context.Context, dests []Destination, msg Message) ([]SendResult, error) {
+	return SendMultiWithOptions(ctx, dests, msg, MultiOptions{})
+}
+
+// SendMultiWithOptions 將同一則訊息並行發送到多個目標
+//
+// 每個目標套用各自的平台、預設值、限流與重試設定。返回的結果與 dests 順序相同；
+// 任一目標失敗時返回 *MultiError。等待並行名額時 ctx 結束，尚未開始的目標記錄
+// ctx.Err()。
+func SendMultiWithOptions(ctx context.Context, dests []Destination, msg Message, opts MultiOptions) ([]SendResult, error) {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultMultiConcurrency
+	}
+
+	results := make([]SendResult, len(dests))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i := range dests {
+		d := &dests[i]
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			results[i] = SendResult{Destination: d.Name, URL: d.URL, Err: ctx.Err()}
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			start := time.Now()
+			err := d.Send(ctx, msg)
+			results[i] = SendResult{
+				Destination: d.Name,
+				URL:         d.URL,
+				Err:         err,
+				Duration:    time.Since(start),
+			}
+		}()
+	}
+	wg.Wait()
+
+	var multiErr *MultiError
+	for _, r := range results {
+		if r.Err == nil {
+			continue
+		}
+		if multiErr == nil {
+			multiErr = &MultiError{Total: len(dests)}
+		}
+		multiErr.Errors = append(multiErr.Errors, &DestinationError{Destination: r.Destination, Err: r.Err})
+	}
+	if multiErr != nil {
+		return results, multiErr
+	}
+	return results, nil
+}
@@ -0,0 +1,142 @@
+package samhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSendMulti_Success(t *testing.T) {
+	url, received := collectMessages(t)
+	dests := []Destination{
+		{Name: "team", URL: url, Channel: "#team"},
+		{Name: "oncall", URL: url, Channel: "#oncall"},
+	}
+
+	results, err := SendMulti(context.Background(), dests, Message{Text: "deploy done"})
+	if err != nil {
+		t.Fatalf("SendMulti() error = %v", err)
+	}
+	if len(results) != 2 || results[0].Destination != "team" || results[1].Destination != "oncall" {
+		t.Errorf("unexpected results: %+v", results)
+	}
+
+	channels := map[string]bool{}
+	for _, m := range received() {
+		channels[m.Channel] = true
+	}
+	if !channels["#team"] || !channels["#oncall"] {
+		t.Errorf("expected per-destination defaults, got %v", channels)
+	}
+}
+
+func TestSendMulti_PartialFailure(t *testing.T) {
+	ok := mockWebhookServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	forbidden := mockWebhookServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	dests := []Destination{
+		{Name: "ok", URL: ok.URL},
+		{Name: "forbidden", URL: forbidden.URL},
+	}
+	results, err := SendMulti(context.Background(), dests, createTestMessage())
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	var multiErr *MultiError
+	if !errors.As(err, &multiErr) {
+		t.Fatalf("expected *MultiError, got %T", err)
+	}
+	if len(multiErr.Errors) != 1 || multiErr.Total != 2 || multiErr.Errors[0].Destination != "forbidden" {
+		t.Errorf("unexpected MultiError: %v", multiErr)
+	}
+
+	var webhookErr *WebhookError
+	if !errors.As(err, &webhookErr) || webhookErr.StatusCode != http.StatusForbidden {
+		t.Errorf("expected WebhookError with 403 via errors.As, got %v", webhookErr)
+	}
+
+	if results[0].Err != nil || results[1].Err == nil {
+		t.Errorf("unexpected per-destination results: %+v", results)
+	}
+}
+
+func TestSendMulti_ErrorsIs(t *testing.T) {
+	dests := []Destination{{Name: "a", URL: "http://example.com", RateLimiter: NewRateLimiter(1, time.Hour)}}
+	dests[0].RateLimiter.Allow()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := SendMulti(ctx, dests, createTestMessage())
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected errors.Is(err, context.Canceled), got %v", err)
+	}
+}
+
+func TestSendMultiWithOptions_CancelWhileWaiting(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var requests int32
+	release := make(chan struct{})
+	server := mockWebhookServer(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		cancel()
+		<-release
+	})
+	t.Cleanup(func() { close(release) })
+
+	dests := []Destination{
+		{Name: "first", URL: server.URL},
+		{Name: "second", URL: server.URL},
+		{Name: "third", URL: server.URL},
+	}
+	results, err := SendMultiWithOptions(ctx, dests, createTestMessage(), MultiOptions{Concurrency: 1})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected errors.Is(err, context.Canceled), got %v", err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Errorf("expected 1 request, got %d", n)
+	}
+	for _, r := range results[1:] {
+		if !errors.Is(r.Err, context.Canceled) || r.Destination == "" || r.URL != server.URL {
+			t.Errorf("expected canceled result for %q, got %+v", r.Destination, r)
+		}
+	}
+}
+
+func TestSendMultiWithOptions_BoundedConcurrency(t *testing.T) {
+	var current, peak int32
+	server := mockWebhookServer(t, func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&current, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&current, -1)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	dests := make([]Destination, 8)
+	for i := range dests {
+		dests[i] = Destination{URL: server.URL}
+	}
+
+	if _, err := SendMultiWithOptions(context.Background(), dests, createTestMessage(), MultiOptions{Concurrency: 2}); err != nil {
+		t.Fatalf("SendMultiWithOptions() error = %v", err)
+	}
+	if p := atomic.LoadInt32(&peak); p > 2 {
+		t.Errorf("expected at most 2 concurrent requests, got %d", p)
+	}
+}
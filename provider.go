@@ -0,0 +1,148 @@
+package samhook
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// 平台名稱常數
+const (
+	ProviderNameSlack      = "slack"
+	ProviderNameMattermost = "mattermost"
+	ProviderNameDiscord    = "discord"
+	ProviderNameTeams      = "teams"
+	ProviderNameGoogleChat = "googlechat"
+	ProviderNameDingTalk   = "dingtalk"
+	ProviderNameFeishu     = "feishu"
+	ProviderNameWeCom      = "wecom"
+	ProviderNameTelegram   = "telegram"
+	ProviderNameRocketChat = "rocketchat"
+	ProviderNameZulip      = "zulip"
+)
+
+// Provider 描述一個 webhook 平台（名稱與訊息限制）
+type Provider interface {
+	// Name 返回平台名稱
+	Name() string
+
+	// Limits 返回平台的訊息大小限制
+	Limits() Limits
+}
+
+// Encoder 由平台實作，將訊息編碼為平台的請求內容
+//
+// 未實作此介面的平台使用 Slack 相容的 JSON 格式。
+type Encoder interface {
+	Encode(webhookURL string, msg Message) ([]byte, error)
+}
+
+// ResponseChecker 由平台實作，判斷回應是否代表成功
+//
+// 失敗時應返回 *WebhookError（例如 NewAPIError）；未實作此介面的平台
+// 僅視 HTTP 200 為成功。
+type ResponseChecker interface {
+	CheckResponse(webhookURL string, statusCode int, body []byte) error
+}
+
+// RequestModifier 由平台實作，在發送前調整請求（例如加入查詢參數或簽章）
+type RequestModifier interface {
+	ModifyRequest(req *http.Request) error
+}
+
+// Splitter 由平台實作，將訊息拆分為多則依序發送（例如平台的單則長度上限）
+type Splitter interface {
+	Split(msg Message) []Message
+}
+
+// SlackProvider Slack incoming webhook
+type SlackProvider struct{}
+
+// Name 返回平台名稱
+func (SlackProvider) Name() string { return ProviderNameSlack }
+
+// Limits 返回 Slack 的訊息限制
+func (SlackProvider) Limits() Limits {
+	return Limits{
+		MaxTextLength:       40000,
+		MaxAttachments:      100,
+		MaxFields:           100,
+		MaxAttachmentText:   40000,
+		MaxTitleLength:      2000,
+		MaxFieldTitleLength: 2000,
+		MaxFieldValueLength: 2000,
+	}
+}
+
+// MattermostProvider Mattermost incoming webhook
+type MattermostProvider struct{}
+
+// Name 返回平台名稱
+func (MattermostProvider) Name() string { return ProviderNameMattermost }
+
+// Limits 返回 Mattermost 的訊息限制
+func (MattermostProvider) Limits() Limits {
+	return Limits{
+		MaxTextLength:       16383,
+		MaxAttachments:      100,
+		MaxFields:           100,
+		MaxAttachmentText:   16383,
+		MaxTitleLength:      2000,
+		MaxFieldTitleLength: 2000,
+		MaxFieldValueLength: 16383,
+	}
+}
+
+// DiscordProvider Discord webhook
+//
+// URL 以 /slack 結尾時使用 Slack 相容格式，否則編碼為 Discord 原生的 embed 格式。
+type DiscordProvider struct{}
+
+// Name 返回平台名稱
+func (DiscordProvider) Name() string { return ProviderNameDiscord }
+
+// Limits 返回 Discord 的訊息限制（attachment 對應 embed）
+func (DiscordProvider) Limits() Limits {
+	return Limits{
+		MaxTextLength:       2000,
+		MaxAttachments:      10,
+		MaxFields:           25,
+		MaxAttachmentText:   4096,
+		MaxTitleLength:      256,
+		MaxFieldTitleLength: 256,
+		MaxFieldValueLength: 1024,
+	}
+}
+
+// 已註冊的平台（依名稱查詢，供設定檔使用）
+var (
+	providersMu sync.RWMutex
+	providers   = map[string]Provider{
+		ProviderNameSlack:      SlackProvider{},
+		ProviderNameMattermost: MattermostProvider{},
+		ProviderNameDiscord:    DiscordProvider{},
+		ProviderNameTeams:      TeamsProvider{},
+		ProviderNameGoogleChat: GoogleChatProvider{},
+		ProviderNameDingTalk:   &DingTalkProvider{},
+		ProviderNameFeishu:     FeishuProvider{},
+		ProviderNameWeCom:      &WeComProvider{},
+		ProviderNameTelegram:   TelegramProvider{},
+		ProviderNameRocketChat: RocketChatProvider{},
+		ProviderNameZulip:      ZulipProvider{},
+	}
+)
+
+// RegisterProvider 註冊平台，同名的平台會被取代
+func RegisterProvider(provider Provider) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+	providers[strings.ToLower(provider.Name())] = provider
+}
+
+// ProviderByName 依名稱（不區分大小寫）查詢已註冊的平台
+func ProviderByName(name string) (Provider, bool) {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+	provider, ok := providers[strings.ToLower(name)]
+	return provider, ok
+}
@@ -0,0 +1,73 @@
+package samhook
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter 權杖桶限流器，可安全地在多個 goroutine 中使用
+type RateLimiter struct {
+	mu     sync.Mutex
+	tokens float64
+	burst  float64
+	rate   float64 // 每秒補充的權杖數
+	last   time.Time
+}
+
+// NewRateLimiter 創建限流器，每 per 時間內最多允許 limit 次（允許一次用完）
+func NewRateLimiter(limit int, per time.Duration) *RateLimiter {
+	if limit <= 0 {
+		limit = 1
+	}
+	if per <= 0 {
+		per = time.Second
+	}
+	return &RateLimiter{
+		tokens: float64(limit),
+		burst:  float64(limit),
+		rate:   float64(limit) / per.Seconds(),
+		last:   time.Now(),
+	}
+}
+
+// Allow 若目前有可用的權杖則消耗一個並返回 true
+func (l *RateLimiter) Allow() bool {
+	return l.reserve() == 0
+}
+
+// Wait 等待直到取得權杖或 ctx 結束
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		delay := l.reserve()
+		if delay == 0 {
+			return nil
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// reserve 嘗試取得權杖，成功返回 0，否則返回需要等待的時間
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
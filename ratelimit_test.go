@@ -0,0 +1,54 @@
+package samhook
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_Allow(t *testing.T) {
+	limiter := NewRateLimiter(3, time.Hour)
+
+	for i := range 3 {
+		if !limiter.Allow() {
+			t.Fatalf("call %d should be allowed", i)
+		}
+	}
+	if limiter.Allow() {
+		t.Error("call beyond limit should be rejected")
+	}
+}
+
+func TestRateLimiter_Refill(t *testing.T) {
+	limiter := NewRateLimiter(1, 20*time.Millisecond)
+
+	if !limiter.Allow() {
+		t.Fatal("first call should be allowed")
+	}
+	if limiter.Allow() {
+		t.Fatal("second call should be rejected")
+	}
+	time.Sleep(30 * time.Millisecond)
+	if !limiter.Allow() {
+		t.Error("call after refill should be allowed")
+	}
+}
+
+func TestRateLimiter_Wait(t *testing.T) {
+	limiter := NewRateLimiter(1, 20*time.Millisecond)
+	limiter.Allow()
+
+	start := time.Now()
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 10*time.Millisecond {
+		t.Errorf("Wait() returned too early: %v", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := limiter.Wait(ctx); err == nil {
+		t.Error("expected error from cancelled context")
+	}
+}
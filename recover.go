@@ -0,0 +1,246 @@
+package samhook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// maxStackLength 附在訊息中的堆疊追蹤最大長度（字元）
+const maxStackLength = 3500
+
+// DefaultPanicReportHeaders 預設附在回報中的請求標頭
+var DefaultPanicReportHeaders = []string{"User-Agent", "X-Request-Id", "X-Forwarded-For"}
+
+// PanicReporterOptions panic 回報選項
+type PanicReporterOptions struct {
+	// Username、IconEmoji、Channel 訊息預設值
+	Username  string
+	IconEmoji string
+	Channel   string
+
+	// Service 服務名稱，顯示在訊息標題中
+	Service string
+
+	// Headers 附在回報中的請求標頭，為 nil 時使用 DefaultPanicReportHeaders
+	Headers []string
+
+	// RateLimiter 限制回報頻率，為 nil 時每分鐘最多 5 次
+	RateLimiter *RateLimiter
+
+	// Repanic 回報後是否重新 panic（預設由中介層返回 500）
+	Repanic bool
+
+	// ClientOptions HTTP 客戶端選項
+	ClientOptions []ClientOption
+
+	// OnError 回報發送失敗時呼叫
+	OnError func(err error)
+}
+
+// PanicReporter 捕捉 panic 與錯誤並發送到 webhook
+type PanicReporter struct {
+	url      string
+	opts     PanicReporterOptions
+	hostname string
+
+	mu         sync.Mutex
+	suppressed int
+
+	// pending 背景發送中的回報
+	pending sync.WaitGroup
+}
+
+// requestContextKey 在 context 中保存請求的鍵值
+type requestContextKey struct{}
+
+// NewPanicReporter 創建發送到指定 webhook 的 panic 回報器
+func NewPanicReporter(url string, opts *PanicReporterOptions) *PanicReporter {
+	p := &PanicReporter{url: url}
+	if opts != nil {
+		p.opts = *opts
+	}
+	if p.opts.Headers == nil {
+		p.opts.Headers = DefaultPanicReportHeaders
+	}
+	if p.opts.RateLimiter == nil {
+		p.opts.RateLimiter = NewRateLimiter(5, time.Minute)
+	}
+	p.hostname, _ = os.Hostname()
+	return p
+}
+
+// Middleware 返回捕捉 panic 的 http.Handler 中介層
+//
+// 發生 panic 時在背景發送回報並立即返回 500（handler 已寫入標頭時 500 無法
+// 送出，只會盡力而為）；請求會放入 context，讓 handler 中啟動的 goroutine
+// 可以透過 Recover 附上請求資訊。程式結束前可呼叫 Wait 等待回報發送完成。
+func (p *PanicReporter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), requestContextKey{}, r)
+		r = r.WithContext(ctx)
+
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			// http.ErrAbortHandler 是刻意中止請求，不需要回報
+			if recovered == http.ErrAbortHandler {
+				panic(recovered)
+			}
+			// 不等待 webhook 回應，避免延遲 500 回應
+			if msg, ok := p.prepare(ctx, "panic", fmt.Sprint(recovered), debug.Stack()); ok {
+				p.pending.Add(1)
+				go func() {
+					defer p.pending.Done()
+					p.send(ctx, msg)
+				}()
+			}
+			if p.opts.Repanic {
+				panic(recovered)
+			}
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		}()
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Recover 捕捉目前 goroutine 的 panic 並回報，需以 defer 直接呼叫：
+//
+//	defer reporter.Recover(ctx)
+func (p *PanicReporter) Recover(ctx context.Context) {
+	recovered := recover()
+	if recovered == nil {
+		return
+	}
+	p.report(ctx, "panic", fmt.Sprint(recovered), debug.Stack())
+	if p.opts.Repanic {
+		panic(recovered)
+	}
+}
+
+// ReportError 回報一般錯誤（附上呼叫端的堆疊追蹤）
+func (p *PanicReporter) ReportError(ctx context.Context, err error) {
+	if err == nil {
+		return
+	}
+	p.report(ctx, "error", err.Error(), debug.Stack())
+}
+
+// Wait 等待 Middleware 在背景發送中的回報完成
+func (p *PanicReporter) Wait() {
+	p.pending.Wait()
+}
+
+// report 在限流允許時發送回報
+func (p *PanicReporter) report(ctx context.Context, kind, detail string, stack []byte) {
+	if msg, ok := p.prepare(ctx, kind, detail, stack); ok {
+		p.send(ctx, msg)
+	}
+}
+
+// prepare 檢查限流並組合回報訊息，被限流時返回 false
+func (p *PanicReporter) prepare(ctx context.Context, kind, detail string, stack []byte) (Message, bool) {
+	p.mu.Lock()
+	if !p.opts.RateLimiter.Allow() {
+		p.suppressed++
+		p.mu.Unlock()
+		return Message{}, false
+	}
+	suppressed := p.suppressed
+	p.suppressed = 0
+	p.mu.Unlock()
+
+	return p.buildMessage(ctx, kind, detail, string(stack), suppressed), true
+}
+
+// send 發送回報，失敗時呼叫 OnError
+func (p *PanicReporter) send(ctx context.Context, msg Message) {
+	// 請求可能已經結束，不沿用其取消訊號
+	err := SendWithContext(context.WithoutCancel(ctx), p.url, msg, p.opts.ClientOptions...)
+	if err != nil && p.opts.OnError != nil {
+		p.opts.OnError(err)
+	}
+}
+
+// buildMessage 組合回報訊息
+func (p *PanicReporter) buildMessage(ctx context.Context, kind, detail, stack string, suppressed int) Message {
+	title := kind + ": " + detail
+	source := p.hostname
+	if p.opts.Service != "" {
+		source = p.opts.Service + "@" + p.hostname
+	}
+
+	var fields []Field
+	if r, ok := ctx.Value(requestContextKey{}).(*http.Request); ok {
+		fields = append(fields,
+			Field{Title: "Method", Value: r.Method, Short: true},
+			Field{Title: "Path", Value: r.URL.Path, Short: true},
+		)
+		for _, h := range p.opts.Headers {
+			if v := r.Header.Get(h); v != "" {
+				fields = append(fields, Field{Title: h, Value: v, Short: true})
+			}
+		}
+	}
+	fields = append(fields, Field{Title: "Host", Value: p.hostname, Short: true})
+	fields = append(fields, buildInfoFields()...)
+	if suppressed > 0 {
+		fields = append(fields, Field{
+			Title: "Suppressed",
+			Value: fmt.Sprintf("%d reports suppressed by rate limit", suppressed),
+		})
+	}
+
+	return Message{
+		Username:  p.opts.Username,
+		IconEmoji: p.opts.IconEmoji,
+		Channel:   p.opts.Channel,
+		Text:      fmt.Sprintf("%s in %s", kind, source),
+		Attachments: []Attachment{
+			{
+				Fallback: title,
+				Color:    Danger,
+				Title:    title,
+				Fields:   fields,
+				Footer:   time.Now().Format(time.RFC3339),
+			},
+			{
+				Fallback: "stack trace",
+				Color:    Danger,
+				Title:    "Stack trace",
+				Text:     "```\n" + truncateRunes(stack, maxStackLength, DefaultTruncateMarker) + "\n```",
+			},
+		},
+	}
+}
+
+// buildInfoFields 從 runtime/debug 讀取建置資訊
+func buildInfoFields() []Field {
+	fields := []Field{{Title: "Go", Value: runtime.Version(), Short: true}}
+
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return fields
+	}
+	if info.Main.Path != "" {
+		module := info.Main.Path
+		if info.Main.Version != "" {
+			module += "@" + info.Main.Version
+		}
+		fields = append(fields, Field{Title: "Module", Value: module, Short: true})
+	}
+	for _, s := range info.Settings {
+		if s.Key == "vcs.revision" {
+			fields = append(fields, Field{Title: "Revision", Value: s.Value, Short: true})
+		}
+	}
+	return fields
+}
@@ -0,0 +1,164 @@
+package samhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPanicReporter_Middleware(t *testing.T) {
+	url, received := collectMessages(t)
+	reporter := NewPanicReporter(url, &PanicReporterOptions{Service: "api"})
+
+	handler := reporter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("nil map")
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/orders", nil)
+	req.Header.Set("User-Agent", "test-agent")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500, got %d", rec.Code)
+	}
+
+	reporter.Wait()
+	msgs := received()
+	if len(msgs) != 1 {
+		t.Fatalf("expected 1 report, got %d", len(msgs))
+	}
+	msg := msgs[0]
+	if !strings.HasPrefix(msg.Text, "panic in api@") {
+		t.Errorf("unexpected text: %q", msg.Text)
+	}
+	if len(msg.Attachments) != 2 {
+		t.Fatalf("expected 2 attachments, got %d", len(msg.Attachments))
+	}
+	if msg.Attachments[0].Title != "panic: nil map" {
+		t.Errorf("unexpected title: %q", msg.Attachments[0].Title)
+	}
+
+	fields := map[string]string{}
+	for _, f := range msg.Attachments[0].Fields {
+		fields[f.Title] = f.Value
+	}
+	for key, want := range map[string]string{"Method": "POST", "Path": "/orders", "User-Agent": "test-agent"} {
+		if fields[key] != want {
+			t.Errorf("field %s = %q, want %q", key, fields[key], want)
+		}
+	}
+	if fields["Go"] == "" {
+		t.Error("expected Go version field")
+	}
+	if !strings.Contains(msg.Attachments[1].Text, "recover_test.go") {
+		t.Error("expected stack trace to include the panicking file")
+	}
+}
+
+func TestPanicReporter_Recover(t *testing.T) {
+	url, received := collectMessages(t)
+	reporter := NewPanicReporter(url, nil)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer reporter.Recover(context.Background())
+		panic(errors.New("worker failed"))
+	}()
+	wg.Wait()
+
+	msgs := received()
+	if len(msgs) != 1 || msgs[0].Attachments[0].Title != "panic: worker failed" {
+		t.Fatalf("unexpected reports: %+v", msgs)
+	}
+}
+
+func TestPanicReporter_RateLimit(t *testing.T) {
+	url, received := collectMessages(t)
+	reporter := NewPanicReporter(url, &PanicReporterOptions{
+		RateLimiter: NewRateLimiter(1, 50*time.Millisecond),
+	})
+
+	for range 5 {
+		reporter.ReportError(context.Background(), errors.New("crash loop"))
+	}
+	if n := len(received()); n != 1 {
+		t.Fatalf("expected 1 report within rate limit, got %d", n)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	reporter.ReportError(context.Background(), errors.New("crash loop"))
+
+	msgs := received()
+	if len(msgs) != 2 {
+		t.Fatalf("expected 2 reports, got %d", len(msgs))
+	}
+	found := false
+	for _, f := range msgs[1].Attachments[0].Fields {
+		if f.Title == "Suppressed" && strings.HasPrefix(f.Value, "4 ") {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected suppressed count in second report")
+	}
+}
+
+func TestPanicReporter_NoPanic(t *testing.T) {
+	requests := 0
+	server := mockWebhookServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	})
+	reporter := NewPanicReporter(server.URL, nil)
+
+	handler := reporter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusNoContent || requests != 0 {
+		t.Errorf("expected passthrough without report, got code %d and %d reports", rec.Code, requests)
+	}
+}
+
+func TestPanicReporter_MiddlewareDoesNotBlock(t *testing.T) {
+	release := make(chan struct{})
+	reported := make(chan struct{})
+	server := mockWebhookServer(t, func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		close(reported)
+	})
+	reporter := NewPanicReporter(server.URL, nil)
+
+	handler := reporter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	// webhook 尚未回應時 500 已經寫出
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500, got %d", rec.Code)
+	}
+	select {
+	case <-reported:
+		t.Fatal("report finished before the webhook was released")
+	default:
+	}
+
+	close(release)
+	reporter.Wait()
+	select {
+	case <-reported:
+	default:
+		t.Error("expected report to be sent after Wait")
+	}
+}
@@ -0,0 +1,126 @@
+package samhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// response_url 的回應類型
+const (
+	ResponseEphemeral = "ephemeral"
+	ResponseInChannel = "in_channel"
+)
+
+// Slack 對 response_url 的限制
+const (
+	ResponseURLLifetime = 30 * time.Minute
+	ResponseURLMaxUses  = 5
+)
+
+// ResponseOptions 發送到 response_url 的選項
+type ResponseOptions struct {
+	// ResponseType ResponseEphemeral（預設，只有觸發者看得到）或 ResponseInChannel
+	ResponseType string `json:"response_type,omitempty"`
+
+	// ReplaceOriginal 以此訊息取代觸發互動的原訊息
+	ReplaceOriginal bool `json:"replace_original,omitempty"`
+
+	// DeleteOriginal 刪除觸發互動的原訊息
+	DeleteOriginal bool `json:"delete_original,omitempty"`
+}
+
+// ResponseURL slash command 與互動訊息提供的 response_url
+//
+// Slack 限制每個 response_url 在 30 分鐘內最多使用 5 次，超過時在發送前
+// 返回驗證錯誤。可安全地在多個 goroutine 中使用。
+type ResponseURL struct {
+	// URL response_url
+	URL string
+
+	// IssuedAt 收到 response_url 的時間，用於計算有效期限
+	IssuedAt time.Time
+
+	// ClientOptions HTTP 客戶端選項
+	ClientOptions []ClientOption
+
+	mu   sync.Mutex
+	uses int
+}
+
+// NewResponseURL 以目前時間作為收到時間創建 ResponseURL
+func NewResponseURL(url string) *ResponseURL {
+	return &ResponseURL{URL: url, IssuedAt: time.Now()}
+}
+
+// Remaining 返回剩餘的可用次數
+func (r *ResponseURL) Remaining() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return ResponseURLMaxUses - r.uses
+}
+
+// Expired 判斷是否已超過有效期限
+func (r *ResponseURL) Expired() bool {
+	return time.Since(r.IssuedAt) > ResponseURLLifetime
+}
+
+// responseURLPayload 發送到 response_url 的請求內容
+type responseURLPayload struct {
+	Message
+	ResponseOptions
+}
+
+// slackResponseURL 判斷 response_url 回應的平台（成功時返回 HTTP 200 與 "ok"）
+type slackResponseURL struct{ SlackProvider }
+
+// CheckResponse 失敗時解析 {"ok":false,"error":..} 回應
+func (slackResponseURL) CheckResponse(webhookURL string, statusCode int, body []byte) error {
+	if statusCode == http.StatusOK {
+		return nil
+	}
+	return slackAPI{}.CheckResponse(webhookURL, statusCode, body)
+}
+
+// Send 發送訊息到 response_url（每次呼叫都會使用一次，發送失敗也會計算）
+func (r *ResponseURL) Send(ctx context.Context, msg Message, opts ResponseOptions) error {
+	if err := r.use(); err != nil {
+		return err
+	}
+	payload := responseURLPayload{Message: msg, ResponseOptions: opts}
+	_, err := apiRequest(ctx, newClient(r.ClientOptions), slackResponseURL{}, http.MethodPost, r.URL, "", payload)
+	return err
+}
+
+// Replace 以訊息取代原訊息
+func (r *ResponseURL) Replace(ctx context.Context, msg Message) error {
+	return r.Send(ctx, msg, ResponseOptions{ReplaceOriginal: true})
+}
+
+// DeleteOriginal 刪除觸發互動的原訊息
+func (r *ResponseURL) DeleteOriginal(ctx context.Context) error {
+	return r.Send(ctx, Message{}, ResponseOptions{DeleteOriginal: true})
+}
+
+// use 檢查有效期限與次數並記錄一次使用
+func (r *ResponseURL) use() error {
+	if r.URL == "" {
+		return NewValidationError(ErrorCodeInvalidMessage, errors.New("response_url is empty"))
+	}
+	if r.Expired() {
+		return NewValidationError(ErrorCodeResponseURLExpired,
+			fmt.Errorf("response_url expired %v after it was issued", ResponseURLLifetime))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.uses >= ResponseURLMaxUses {
+		return NewValidationError(ErrorCodeResponseURLExhausted,
+			fmt.Errorf("response_url can only be used %d times", ResponseURLMaxUses))
+	}
+	r.uses++
+	return nil
+}
@@ -0,0 +1,90 @@
+package samhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bytedance/sonic"
+)
+
+func TestResponseURL_Send(t *testing.T) {
+	var payloads []map[string]any
+	server := mockWebhookServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]any
+		sonic.ConfigDefault.NewDecoder(r.Body).Decode(&payload)
+		payloads = append(payloads, payload)
+		w.Write([]byte("ok"))
+	})
+
+	r := NewResponseURL(server.URL)
+	ctx := context.Background()
+	if err := r.Send(ctx, Message{Text: "已收到"}, ResponseOptions{ResponseType: ResponseInChannel}); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if err := r.Replace(ctx, Message{Text: "已核准"}); err != nil {
+		t.Fatalf("Replace() error = %v", err)
+	}
+	if err := r.DeleteOriginal(ctx); err != nil {
+		t.Fatalf("DeleteOriginal() error = %v", err)
+	}
+
+	if payloads[0]["response_type"] != ResponseInChannel || payloads[0]["text"] != "已收到" {
+		t.Errorf("unexpected payload: %v", payloads[0])
+	}
+	if payloads[1]["replace_original"] != true {
+		t.Errorf("expected replace_original, got %v", payloads[1])
+	}
+	if payloads[2]["delete_original"] != true {
+		t.Errorf("expected delete_original, got %v", payloads[2])
+	}
+	if r.Remaining() != ResponseURLMaxUses-3 {
+		t.Errorf("Remaining() = %d", r.Remaining())
+	}
+}
+
+func TestResponseURL_Limits(t *testing.T) {
+	server := mockWebhookServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	})
+
+	tests := []struct {
+		name     string
+		issuedAt time.Time
+		sends    int
+		wantCode string
+	}{
+		{name: "次數用完", issuedAt: time.Now(), sends: ResponseURLMaxUses, wantCode: ErrorCodeResponseURLExhausted},
+		{name: "超過有效期限", issuedAt: time.Now().Add(-ResponseURLLifetime - time.Second), wantCode: ErrorCodeResponseURLExpired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &ResponseURL{URL: server.URL, IssuedAt: tt.issuedAt}
+			for i := 0; i < tt.sends; i++ {
+				if err := r.Send(context.Background(), createTestMessage(), ResponseOptions{}); err != nil {
+					t.Fatalf("send %d: %v", i, err)
+				}
+			}
+			err := r.Send(context.Background(), createTestMessage(), ResponseOptions{})
+			var webhookErr *WebhookError
+			if !errors.As(err, &webhookErr) || !webhookErr.IsValidationError() || webhookErr.ErrorCode != tt.wantCode {
+				t.Errorf("expected %s, got %v", tt.wantCode, err)
+			}
+		})
+	}
+}
+
+func TestResponseURL_APIError(t *testing.T) {
+	server := mockWebhookServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"ok":false,"error":"expired_url"}`))
+	})
+
+	err := NewResponseURL(server.URL).Send(context.Background(), createTestMessage(), ResponseOptions{})
+	var webhookErr *WebhookError
+	if !errors.As(err, &webhookErr) || webhookErr.ProviderCode != "expired_url" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package samhook
 
 import (
+	"context"
 	"math"
 	"math/rand"
 	"time"
@@ -51,24 +52,54 @@ func (eb *ExponentialBackoff) NextInterval(attempt int) time.Duration {
 
 // SendWithRetry 帶重試的發送，支援自訂客戶端配置
 func SendWithRetry(url string, msg Message, opts RetryOptions, clientOpts ...ClientOption) error {
+	return SendWithRetryContext(context.Background(), url, msg, opts, clientOpts...)
+}
+
+// SendWithRetryContext 帶重試與 Context 的發送，ctx 結束時停止重試
+func SendWithRetryContext(ctx context.Context, url string, msg Message, opts RetryOptions, clientOpts ...ClientOption) error {
+	return sendWithRetry(ctx, url, nil, msg, opts, clientOpts)
+}
+
+// sendWithRetry 以指定平台帶重試發送，provider 為 nil 時依 URL 偵測
+//
+// 平台實作 Splitter 時每一則拆分後的訊息分別重試，已送出的部分不會重送。
+func sendWithRetry(ctx context.Context, url string, provider Provider, msg Message, opts RetryOptions, clientOpts []ClientOption) error {
+	client := newClient(clientOpts)
+	provider = resolveProvider(url, provider)
+
+	for _, m := range splitMessageFor(provider, msg) {
+		err := retry(ctx, opts, func() error {
+			return sendSingle(ctx, client, url, provider, m)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// retry 依選項重複執行 send，直到成功、遇到不可重試的錯誤或 ctx 結束
+//
+// 錯誤帶有 RetryAfter 且大於計算出的間隔時，改為等待 RetryAfter。
+func retry(ctx context.Context, opts RetryOptions, send func() error) error {
 	var lastErr error
 	interval := opts.Interval
 
 	for i := 0; i <= opts.MaxRetries; i++ {
-		err := SendWithOptions(url, msg, clientOpts...)
+		err := send()
 		if err == nil {
 			return nil
 		}
 
 		// 檢查是否可重試
-		if webhookErr, ok := err.(*WebhookError); ok {
-			if !isRetryable(webhookErr) {
-				return err
-			}
-		} else {
+		webhookErr, ok := err.(*WebhookError)
+		if !ok {
 			// 非 WebhookError 預設不重試
 			return err
 		}
+		if !isRetryable(webhookErr) {
+			return err
+		}
 
 		lastErr = err
 		if i < opts.MaxRetries {
@@ -76,7 +107,17 @@ func SendWithRetry(url string, msg Message, opts RetryOptions, clientOpts ...Cli
 			if opts.Backoff != nil {
 				interval = opts.Backoff.NextInterval(i)
 			}
-			time.Sleep(interval)
+			wait := interval
+			if webhookErr.RetryAfter > wait {
+				wait = webhookErr.RetryAfter
+			}
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return lastErr
+			case <-timer.C:
+			}
 		}
 	}
 	return lastErr
@@ -88,12 +129,12 @@ func isRetryable(err *WebhookError) bool {
 	if err.IsNetworkError() {
 		return true
 	}
-	// 5xx 錯誤可以重試
-	if err.IsAPIError() && err.StatusCode >= 500 {
+	// 5xx 錯誤可以重試（含以 HTTP 200 返回的平台暫時性錯誤）
+	if err.IsAPIError() && (err.StatusCode >= 500 || err.ErrorCode == ErrorCodeAPIServerError) {
 		return true
 	}
-	// 429 速率限制可以重試
-	if err.IsAPIError() && err.StatusCode == 429 {
+	// 429 速率限制可以重試（含以 HTTP 200 返回的平台限流錯誤）
+	if err.IsAPIError() && (err.StatusCode == 429 || err.ErrorCode == ErrorCodeAPIRateLimit) {
 		return true
 	}
 	// 4xx 錯誤不重試
@@ -0,0 +1,101 @@
+package samhook
+
+import (
+	"net/http"
+
+	"github.com/bytedance/sonic"
+)
+
+// RocketChatProvider Rocket.Chat incoming webhook
+//
+// Rocket.Chat 接受類似 Slack 的格式，但以 alias、emoji 與 avatar 取代
+// username、icon_emoji 與 icon_url，attachment 可以預設收合。
+type RocketChatProvider struct {
+	// Alias 顯示名稱，為空時使用 Message.Username
+	Alias string
+
+	// Emoji 頭像 emoji（例如 :rocket:），為空時使用 Message.IconEmoji
+	Emoji string
+
+	// Avatar 頭像圖片 URL，為空時使用 Message.IconURL
+	Avatar string
+
+	// Collapsed 是否預設收合所有 attachment
+	Collapsed bool
+}
+
+// Name 返回平台名稱
+func (RocketChatProvider) Name() string { return ProviderNameRocketChat }
+
+// Limits 返回 Rocket.Chat 的訊息限制（預設設定的 Message_MaxAllowedSize）
+func (RocketChatProvider) Limits() Limits {
+	return Limits{
+		MaxTextLength:       5000,
+		MaxAttachments:      100,
+		MaxFields:           100,
+		MaxAttachmentText:   5000,
+		MaxTitleLength:      2000,
+		MaxFieldTitleLength: 2000,
+		MaxFieldValueLength: 5000,
+	}
+}
+
+type rocketChatPayload struct {
+	Alias       string                 `json:"alias,omitempty"`
+	Emoji       string                 `json:"emoji,omitempty"`
+	Avatar      string                 `json:"avatar,omitempty"`
+	Channel     string                 `json:"channel,omitempty"`
+	Text        string                 `json:"text,omitempty"`
+	Attachments []rocketChatAttachment `json:"attachments,omitempty"`
+}
+
+type rocketChatAttachment struct {
+	Attachment
+	Collapsed bool `json:"collapsed,omitempty"`
+}
+
+// rocketChatResponse Rocket.Chat 的回應格式
+type rocketChatResponse struct {
+	Success   *bool  `json:"success"`
+	Error     string `json:"error"`
+	ErrorType string `json:"errorType"`
+}
+
+// Encode 將訊息編碼為 Rocket.Chat 格式
+func (p RocketChatProvider) Encode(webhookURL string, msg Message) ([]byte, error) {
+	payload := rocketChatPayload{
+		Alias:   firstNonEmpty(p.Alias, msg.Username),
+		Emoji:   firstNonEmpty(p.Emoji, msg.IconEmoji),
+		Avatar:  firstNonEmpty(p.Avatar, msg.IconURL),
+		Channel: msg.Channel,
+		Text:    msg.Text,
+	}
+	for _, a := range msg.Attachments {
+		payload.Attachments = append(payload.Attachments, rocketChatAttachment{Attachment: a, Collapsed: p.Collapsed})
+	}
+
+	data, err := sonic.Marshal(payload)
+	if err != nil {
+		return nil, NewSerializationError(err)
+	}
+	return data, nil
+}
+
+// CheckResponse 解析 {"success":false,"error":..} 回應
+func (RocketChatProvider) CheckResponse(webhookURL string, statusCode int, body []byte) error {
+	var resp rocketChatResponse
+	if err := sonic.Unmarshal(body, &resp); err != nil || resp.Success == nil {
+		if statusCode == http.StatusOK {
+			return nil
+		}
+		return NewAPIError(webhookURL, statusCode, string(body))
+	}
+	if *resp.Success && statusCode == http.StatusOK {
+		return nil
+	}
+	apiErr := NewProviderError(webhookURL, statusCode, string(body), resp.ErrorType, resp.Error)
+	if resp.ErrorType == "" {
+		apiErr.Message = "API error: " + resp.Error
+	}
+	return apiErr
+}
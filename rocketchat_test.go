@@ -0,0 +1,72 @@
+package samhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/bytedance/sonic"
+)
+
+func TestRocketChatProvider_Encode(t *testing.T) {
+	msg := Message{
+		Username:    "bot",
+		IconEmoji:   ":robot:",
+		Channel:     "#ops",
+		Text:        "部署完成",
+		Attachments: []Attachment{{Title: "api", Color: Good}},
+	}
+
+	tests := []struct {
+		name      string
+		provider  RocketChatProvider
+		wantAlias string
+		wantEmoji string
+	}{
+		{name: "使用訊息欄位", provider: RocketChatProvider{}, wantAlias: "bot", wantEmoji: ":robot:"},
+		{name: "provider 設定優先", provider: RocketChatProvider{Alias: "deployer", Emoji: ":rocket:", Collapsed: true}, wantAlias: "deployer", wantEmoji: ":rocket:"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.provider.Encode("", msg)
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			var payload map[string]any
+			if err := sonic.Unmarshal(data, &payload); err != nil {
+				t.Fatalf("invalid JSON: %v", err)
+			}
+			if payload["alias"] != tt.wantAlias || payload["emoji"] != tt.wantEmoji {
+				t.Errorf("unexpected payload: %s", data)
+			}
+			if _, ok := payload["username"]; ok {
+				t.Errorf("expected username to be translated to alias: %s", data)
+			}
+			attachment := payload["attachments"].([]any)[0].(map[string]any)
+			if attachment["title"] != "api" {
+				t.Errorf("expected attachment fields to be kept: %s", data)
+			}
+			if collapsed, _ := attachment["collapsed"].(bool); collapsed != tt.provider.Collapsed {
+				t.Errorf("collapsed = %v, want %v", collapsed, tt.provider.Collapsed)
+			}
+		})
+	}
+}
+
+func TestRocketChatProvider_CheckResponse(t *testing.T) {
+	server := mockWebhookServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"success":false,"error":"Invalid integration"}`))
+	})
+
+	err := SendWithProvider(context.Background(), RocketChatProvider{}, server.URL, createTestMessage())
+	var webhookErr *WebhookError
+	if !errors.As(err, &webhookErr) || webhookErr.StatusCode != http.StatusBadRequest || webhookErr.Message != "API error: Invalid integration" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := (RocketChatProvider{}).CheckResponse("u", http.StatusOK, []byte(`{"success":true}`)); err != nil {
+		t.Errorf("expected success, got %v", err)
+	}
+}
@@ -0,0 +1,276 @@
+package router
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/circleyu/samhook"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultEnvPrefix 預設的環境變數前綴
+const DefaultEnvPrefix = "SAMHOOK"
+
+// Config 路由設定
+type Config struct {
+	// Destinations 具名的發送目標
+	Destinations map[string]DestinationConfig `json:"destinations" yaml:"destinations"`
+
+	// Routes 依序比對的路由規則
+	Routes []RouteConfig `json:"routes" yaml:"routes"`
+
+	// Default 沒有規則符合時使用的目標
+	Default []string `json:"default" yaml:"default"`
+}
+
+// DestinationConfig 發送目標設定
+type DestinationConfig struct {
+	URL       string `json:"url" yaml:"url"`
+	Provider  string `json:"provider" yaml:"provider"`
+	Username  string `json:"username" yaml:"username"`
+	IconURL   string `json:"icon_url" yaml:"icon_url"`
+	IconEmoji string `json:"icon_emoji" yaml:"icon_emoji"`
+	Channel   string `json:"channel" yaml:"channel"`
+
+	// Limit 超出平台限制時的處理方式：reject、truncate 或 split
+	Limit string `json:"limit" yaml:"limit"`
+
+	Timeout   Duration         `json:"timeout" yaml:"timeout"`
+	Retry     *RetryConfig     `json:"retry" yaml:"retry"`
+	RateLimit *RateLimitConfig `json:"rate_limit" yaml:"rate_limit"`
+}
+
+// RetryConfig 重試設定
+type RetryConfig struct {
+	MaxRetries  int      `json:"max_retries" yaml:"max_retries"`
+	Interval    Duration `json:"interval" yaml:"interval"`
+	MaxInterval Duration `json:"max_interval" yaml:"max_interval"`
+}
+
+// RateLimitConfig 限流設定：每 Per 時間內最多 Limit 則
+type RateLimitConfig struct {
+	Limit int      `json:"limit" yaml:"limit"`
+	Per   Duration `json:"per" yaml:"per"`
+}
+
+// RouteConfig 路由規則
+type RouteConfig struct {
+	Name         string   `json:"name" yaml:"name"`
+	Match        Match    `json:"match" yaml:"match"`
+	Destinations []string `json:"destinations" yaml:"destinations"`
+
+	// Continue 符合後是否繼續比對後續規則
+	Continue bool `json:"continue" yaml:"continue"`
+}
+
+// Match 比對條件，所有非空條件都必須符合
+type Match struct {
+	// Severity 任一嚴重程度相符（不區分大小寫）
+	Severity []string `json:"severity" yaml:"severity"`
+
+	// Tags 任一標籤相符
+	Tags []string `json:"tags" yaml:"tags"`
+
+	// Source 任一來源相符，支援 path.Match 萬用字元
+	Source []string `json:"source" yaml:"source"`
+}
+
+// Duration 可從 "1s"、"500ms" 等字串解析的時間長度，不帶單位的整數視為秒數
+type Duration time.Duration
+
+// UnmarshalJSON 解析 JSON 字串或秒數
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	return d.parse(s)
+}
+
+// UnmarshalYAML 解析 YAML 字串
+func (d *Duration) UnmarshalYAML(node *yaml.Node) error {
+	return d.parse(node.Value)
+}
+
+func (d *Duration) parse(s string) error {
+	if s == "" || s == "null" {
+		*d = 0
+		return nil
+	}
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		if n > math.MaxInt64/int64(time.Second) || n < math.MinInt64/int64(time.Second) {
+			return fmt.Errorf("invalid duration %q: out of range", s)
+		}
+		*d = Duration(time.Duration(n) * time.Second)
+		return nil
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("invalid duration %q: %w", s, err)
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// LoadConfig 讀取設定檔，依副檔名（.yaml、.yml 或 .json）選擇格式
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseConfig(data, filepath.Ext(path))
+}
+
+// ParseConfig 解析設定內容，format 為 "json" 或 "yaml"（可帶 "."）
+func ParseConfig(data []byte, format string) (*Config, error) {
+	var cfg Config
+	switch strings.ToLower(strings.TrimPrefix(format, ".")) {
+	case "yaml", "yml":
+		if err := yaml.Unmarshal(data, &cfg); err != nil {
+			return nil, fmt.Errorf("invalid YAML config: %w", err)
+		}
+	case "json":
+		if err := sonic.Unmarshal(data, &cfg); err != nil {
+			return nil, fmt.Errorf("invalid JSON config: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported config format %q", format)
+	}
+	return &cfg, nil
+}
+
+var envNamePattern = regexp.MustCompile(`[^A-Z0-9]+`)
+
+// applyEnv 以環境變數覆蓋目標設定，例如 SAMHOOK_DEST_ONCALL_URL
+//
+// 支援字串、數字與時間長度欄位（重試與限流設定使用 RETRY_、RATE_LIMIT 前綴），
+// 無法解析的值返回錯誤。
+func (c *Config) applyEnv(prefix string, lookup func(string) (string, bool)) error {
+	for name, dest := range c.Destinations {
+		base := prefix + "_DEST_" + envNamePattern.ReplaceAllString(strings.ToUpper(name), "_") + "_"
+		strs := map[string]*string{
+			"URL":        &dest.URL,
+			"PROVIDER":   &dest.Provider,
+			"USERNAME":   &dest.Username,
+			"ICON_URL":   &dest.IconURL,
+			"ICON_EMOJI": &dest.IconEmoji,
+			"CHANNEL":    &dest.Channel,
+			"LIMIT":      &dest.Limit,
+		}
+		for key, field := range strs {
+			if v, ok := lookup(base + key); ok {
+				*field = v
+			}
+		}
+
+		// 重試與限流設定為指標，複製後再修改，避免影響呼叫端的 Config
+		var retry RetryConfig
+		if dest.Retry != nil {
+			retry = *dest.Retry
+		}
+		var rate RateLimitConfig
+		if dest.RateLimit != nil {
+			rate = *dest.RateLimit
+		}
+		ints := map[string]*int{
+			"RETRY_MAX_RETRIES": &retry.MaxRetries,
+			"RATE_LIMIT":        &rate.Limit,
+		}
+		durations := map[string]*Duration{
+			"TIMEOUT":            &dest.Timeout,
+			"RETRY_INTERVAL":     &retry.Interval,
+			"RETRY_MAX_INTERVAL": &retry.MaxInterval,
+			"RATE_LIMIT_PER":     &rate.Per,
+		}
+		for key, field := range ints {
+			if v, ok := lookup(base + key); ok {
+				n, err := strconv.Atoi(strings.TrimSpace(v))
+				if err != nil {
+					return fmt.Errorf("%s: invalid number %q", base+key, v)
+				}
+				*field = n
+			}
+		}
+		for key, field := range durations {
+			if v, ok := lookup(base + key); ok {
+				if err := field.parse(strings.TrimSpace(v)); err != nil {
+					return fmt.Errorf("%s: %w", base+key, err)
+				}
+			}
+		}
+		if dest.Retry != nil || retry != (RetryConfig{}) {
+			dest.Retry = &retry
+		}
+		if dest.RateLimit != nil || rate != (RateLimitConfig{}) {
+			dest.RateLimit = &rate
+		}
+		c.Destinations[name] = dest
+	}
+	return nil
+}
+
+// buildDestination 將設定轉換為 samhook.Destination
+func buildDestination(name string, cfg DestinationConfig) (*samhook.Destination, error) {
+	if err := samhook.ValidateWebhookURL(cfg.URL); err != nil {
+		return nil, fmt.Errorf("destination %q: %w", name, err)
+	}
+
+	dest := &samhook.Destination{
+		Name:      name,
+		URL:       cfg.URL,
+		Username:  cfg.Username,
+		IconURL:   cfg.IconURL,
+		IconEmoji: cfg.IconEmoji,
+		Channel:   cfg.Channel,
+	}
+
+	if cfg.Provider != "" {
+		provider, ok := samhook.ProviderByName(cfg.Provider)
+		if !ok {
+			return nil, fmt.Errorf("destination %q: unknown provider %q", name, cfg.Provider)
+		}
+		dest.Provider = provider
+	}
+
+	switch strings.ToLower(cfg.Limit) {
+	case "", "reject":
+		dest.LimitStrategy = samhook.LimitReject
+	case "truncate":
+		dest.LimitStrategy = samhook.LimitTruncate
+	case "split":
+		dest.LimitStrategy = samhook.LimitSplit
+	default:
+		return nil, fmt.Errorf("destination %q: unknown limit strategy %q", name, cfg.Limit)
+	}
+
+	if cfg.Timeout > 0 {
+		dest.ClientOptions = append(dest.ClientOptions, samhook.WithTimeout(time.Duration(cfg.Timeout)))
+	}
+
+	if cfg.Retry != nil {
+		retry := samhook.DefaultRetryOptions
+		retry.MaxRetries = cfg.Retry.MaxRetries
+		if cfg.Retry.Interval > 0 {
+			retry.Interval = time.Duration(cfg.Retry.Interval)
+			backoff := *retry.Backoff
+			backoff.InitialInterval = retry.Interval
+			retry.Backoff = &backoff
+		}
+		if cfg.Retry.MaxInterval > 0 {
+			backoff := *retry.Backoff
+			backoff.MaxInterval = time.Duration(cfg.Retry.MaxInterval)
+			retry.Backoff = &backoff
+		}
+		dest.Retry = &retry
+	}
+
+	if cfg.RateLimit != nil {
+		dest.RateLimiter = samhook.NewRateLimiter(cfg.RateLimit.Limit, time.Duration(cfg.RateLimit.Per))
+	}
+
+	return dest, nil
+}
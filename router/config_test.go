@@ -0,0 +1,198 @@
+package router
+
+import (
+	"testing"
+	"time"
+
+	"github.com/circleyu/samhook"
+)
+
+const testYAML = `
+destinations:
+  oncall:
+    url: https://hooks.slack.com/services/T/B/X
+    provider: slack
+    username: pager
+    icon_emoji: ":fire:"
+    channel: "#oncall"
+    limit: split
+    timeout: 5s
+    retry:
+      max_retries: 2
+      interval: 100ms
+    rate_limit:
+      limit: 10
+      per: 1m
+  team:
+    url: https://chat.example.com/hooks/abc
+    provider: mattermost
+routes:
+  - name: critical
+    match:
+      severity: [critical]
+    destinations: [oncall, team]
+default: [team]
+`
+
+const testJSON = `{
+  "destinations": {
+    "team": {"url": "https://chat.example.com/hooks/abc", "retry": {"max_retries": 1, "interval": "2s"}}
+  },
+  "default": ["team"]
+}`
+
+func TestParseConfig_YAML(t *testing.T) {
+	cfg, err := ParseConfig([]byte(testYAML), ".yaml")
+	if err != nil {
+		t.Fatalf("ParseConfig() error = %v", err)
+	}
+
+	oncall := cfg.Destinations["oncall"]
+	if oncall.Username != "pager" || oncall.Channel != "#oncall" || oncall.Limit != "split" {
+		t.Errorf("unexpected destination: %+v", oncall)
+	}
+	if time.Duration(oncall.Timeout) != 5*time.Second {
+		t.Errorf("expected 5s timeout, got %v", time.Duration(oncall.Timeout))
+	}
+	if oncall.Retry == nil || oncall.Retry.MaxRetries != 2 || time.Duration(oncall.Retry.Interval) != 100*time.Millisecond {
+		t.Errorf("unexpected retry: %+v", oncall.Retry)
+	}
+	if oncall.RateLimit == nil || oncall.RateLimit.Limit != 10 || time.Duration(oncall.RateLimit.Per) != time.Minute {
+		t.Errorf("unexpected rate limit: %+v", oncall.RateLimit)
+	}
+	if len(cfg.Routes) != 1 || cfg.Routes[0].Match.Severity[0] != "critical" {
+		t.Errorf("unexpected routes: %+v", cfg.Routes)
+	}
+}
+
+func TestParseConfig_JSON(t *testing.T) {
+	cfg, err := ParseConfig([]byte(testJSON), "json")
+	if err != nil {
+		t.Fatalf("ParseConfig() error = %v", err)
+	}
+	if time.Duration(cfg.Destinations["team"].Retry.Interval) != 2*time.Second {
+		t.Errorf("unexpected retry interval: %v", cfg.Destinations["team"].Retry.Interval)
+	}
+	if _, err := ParseConfig([]byte(testJSON), "toml"); err == nil {
+		t.Error("expected unsupported format error")
+	}
+}
+
+func TestBuildDestination(t *testing.T) {
+	cfg, _ := ParseConfig([]byte(testYAML), "yaml")
+	dest, err := buildDestination("oncall", cfg.Destinations["oncall"])
+	if err != nil {
+		t.Fatalf("buildDestination() error = %v", err)
+	}
+	if dest.Provider.Name() != samhook.ProviderNameSlack || dest.LimitStrategy != samhook.LimitSplit {
+		t.Errorf("unexpected destination: %+v", dest)
+	}
+	if dest.Retry == nil || dest.Retry.MaxRetries != 2 || dest.Retry.Backoff.InitialInterval != 100*time.Millisecond {
+		t.Errorf("unexpected retry: %+v", dest.Retry)
+	}
+	if dest.RateLimiter == nil || len(dest.ClientOptions) != 1 {
+		t.Error("expected rate limiter and timeout option")
+	}
+
+	tests := []struct {
+		name string
+		cfg  DestinationConfig
+	}{
+		{"無效的 URL", DestinationConfig{URL: "ftp://example.com"}},
+		{"未知的平台", DestinationConfig{URL: "https://example.com", Provider: "pager"}},
+		{"未知的限制策略", DestinationConfig{URL: "https://example.com", Limit: "drop"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := buildDestination("x", tt.cfg); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestConfig_ApplyEnv(t *testing.T) {
+	cfg, _ := ParseConfig([]byte(testYAML), "yaml")
+	originalRetry := cfg.Destinations["oncall"].Retry
+	env := map[string]string{
+		"APP_DEST_ONCALL_URL":               "https://override.example.com/hook",
+		"APP_DEST_ONCALL_CHANNEL":           "#override",
+		"APP_DEST_ONCALL_TIMEOUT":           "30",
+		"APP_DEST_ONCALL_RETRY_MAX_RETRIES": "5",
+		"APP_DEST_TEAM_RATE_LIMIT":          "3",
+		"APP_DEST_TEAM_RATE_LIMIT_PER":      "1m",
+	}
+	lookup := func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	}
+	if err := cfg.applyEnv("APP", lookup); err != nil {
+		t.Fatalf("applyEnv() error = %v", err)
+	}
+
+	oncall := cfg.Destinations["oncall"]
+	if oncall.URL != "https://override.example.com/hook" || oncall.Channel != "#override" {
+		t.Errorf("env overrides not applied: %+v", oncall)
+	}
+	if oncall.Username != "pager" {
+		t.Errorf("unrelated field changed: %q", oncall.Username)
+	}
+	if time.Duration(oncall.Timeout) != 30*time.Second {
+		t.Errorf("expected 30s timeout, got %v", time.Duration(oncall.Timeout))
+	}
+	if oncall.Retry.MaxRetries != 5 || time.Duration(oncall.Retry.Interval) != 100*time.Millisecond {
+		t.Errorf("unexpected retry: %+v", oncall.Retry)
+	}
+	if originalRetry.MaxRetries != 2 {
+		t.Errorf("original retry config modified: %+v", originalRetry)
+	}
+	team := cfg.Destinations["team"]
+	if team.RateLimit == nil || team.RateLimit.Limit != 3 || time.Duration(team.RateLimit.Per) != time.Minute {
+		t.Errorf("unexpected rate limit: %+v", team.RateLimit)
+	}
+
+	env = map[string]string{"APP_DEST_TEAM_RETRY_INTERVAL": "soon"}
+	if err := cfg.applyEnv("APP", lookup); err == nil {
+		t.Error("expected error for invalid duration")
+	}
+	env = map[string]string{"APP_DEST_TEAM_RATE_LIMIT": "ten"}
+	if err := cfg.applyEnv("APP", lookup); err == nil {
+		t.Error("expected error for invalid number")
+	}
+}
+
+func TestDuration_Parse(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"帶單位", "500ms", 500 * time.Millisecond, false},
+		{"不帶單位的整數視為秒數", "5", 5 * time.Second, false},
+		{"空值", "", 0, false},
+		{"null", "null", 0, false},
+		{"無效的字串", "soon", 0, true},
+		{"超出範圍", "9223372036854775807", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d Duration
+			err := d.parse(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && time.Duration(d) != tt.want {
+				t.Errorf("parse() = %v, want %v", time.Duration(d), tt.want)
+			}
+		})
+	}
+
+	cfg, err := ParseConfig([]byte(`{"destinations": {"team": {"url": "https://chat.example.com/hooks/abc", "timeout": 10}}}`), "json")
+	if err != nil {
+		t.Fatalf("ParseConfig() error = %v", err)
+	}
+	if time.Duration(cfg.Destinations["team"].Timeout) != 10*time.Second {
+		t.Errorf("expected 10s JSON timeout, got %v", time.Duration(cfg.Destinations["team"].Timeout))
+	}
+}
@@ -0,0 +1,291 @@
+// Package router 依設定檔將訊息路由到具名的發送目標
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/circleyu/samhook"
+)
+
+// ErrNoRoute 沒有符合的路由規則且未設定預設目標
+var ErrNoRoute = errors.New("no route matched")
+
+// RouteKey 用於比對路由規則的訊息屬性
+type RouteKey struct {
+	Severity string
+	Tags     []string
+	Source   string
+}
+
+// Options 路由器選項
+type Options struct {
+	// EnvPrefix 環境變數前綴，為空時使用 DefaultEnvPrefix
+	EnvPrefix string
+
+	// LookupEnv 讀取環境變數，為 nil 時使用 os.LookupEnv
+	LookupEnv func(key string) (string, bool)
+
+	// OnReload 每次重新載入後呼叫，失敗時 err 不為 nil 且保留原本的設定
+	OnReload func(err error)
+}
+
+// Router 將訊息依規則發送到一個或多個目標，可安全地在多個 goroutine 中使用
+type Router struct {
+	opts Options
+	path string
+
+	mu    sync.RWMutex
+	table *table
+
+	// 設定檔狀態（用於偵測變更）
+	modTime time.Time
+	size    int64
+}
+
+// table 由設定建立的路由表
+type table struct {
+	destinations map[string]*samhook.Destination
+	routes       []RouteConfig
+	defaults     []string
+}
+
+// New 由設定建立路由器
+func New(cfg *Config, opts *Options) (*Router, error) {
+	r := &Router{}
+	if opts != nil {
+		r.opts = *opts
+	}
+	if r.opts.EnvPrefix == "" {
+		r.opts.EnvPrefix = DefaultEnvPrefix
+	}
+	if r.opts.LookupEnv == nil {
+		r.opts.LookupEnv = os.LookupEnv
+	}
+
+	t, err := r.build(cfg)
+	if err != nil {
+		return nil, err
+	}
+	r.table = t
+	return r, nil
+}
+
+// NewFromFile 由設定檔建立路由器，可搭配 Watch 在檔案變更時重新載入
+func NewFromFile(path string, opts *Options) (*Router, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	r, err := New(cfg, opts)
+	if err != nil {
+		return nil, err
+	}
+	r.path = path
+	r.modTime = info.ModTime()
+	r.size = info.Size()
+	return r, nil
+}
+
+// Send 將訊息並行發送到所有符合 key 的目標，任一目標失敗時返回 *samhook.MultiError
+func (r *Router) Send(ctx context.Context, key RouteKey, msg samhook.Message) error {
+	_, err := r.SendWithResults(ctx, key, msg)
+	return err
+}
+
+// SendWithResults 與 Send 相同，並返回每個目標的發送結果
+func (r *Router) SendWithResults(ctx context.Context, key RouteKey, msg samhook.Message) ([]samhook.SendResult, error) {
+	matched, err := r.Match(key)
+	if err != nil {
+		return nil, err
+	}
+
+	dests := make([]samhook.Destination, len(matched))
+	for i, d := range matched {
+		dests[i] = *d
+	}
+	return samhook.SendMulti(ctx, dests, msg)
+}
+
+// Match 返回符合 key 的目標（依規則順序，不重複）
+func (r *Router) Match(key RouteKey) ([]*samhook.Destination, error) {
+	r.mu.RLock()
+	t := r.table
+	r.mu.RUnlock()
+
+	var names []string
+	for _, route := range t.routes {
+		if !route.Match.matches(key) {
+			continue
+		}
+		names = append(names, route.Destinations...)
+		if !route.Continue {
+			break
+		}
+	}
+	if len(names) == 0 {
+		names = t.defaults
+	}
+	if len(names) == 0 {
+		return nil, ErrNoRoute
+	}
+
+	var dests []*samhook.Destination
+	seen := make(map[string]bool)
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		dests = append(dests, t.destinations[name])
+	}
+	return dests, nil
+}
+
+// Destination 依名稱返回目標
+func (r *Router) Destination(name string) (*samhook.Destination, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	d, ok := r.table.destinations[name]
+	return d, ok
+}
+
+// Reload 重新讀取設定檔，失敗時保留原本的設定
+func (r *Router) Reload() error {
+	if r.path == "" {
+		return errors.New("router was not created from a file")
+	}
+	info, err := os.Stat(r.path)
+	if err != nil {
+		return err
+	}
+	cfg, err := LoadConfig(r.path)
+	if err != nil {
+		return err
+	}
+	t, err := r.build(cfg)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.table = t
+	r.modTime = info.ModTime()
+	r.size = info.Size()
+	r.mu.Unlock()
+	return nil
+}
+
+// Watch 每隔 interval 檢查設定檔，變更時重新載入，直到 ctx 結束
+//
+// 此函數會阻塞，通常在獨立的 goroutine 中執行；不是由設定檔建立的路由器會直接返回。
+func (r *Router) Watch(ctx context.Context, interval time.Duration) {
+	if r.path == "" {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		info, err := os.Stat(r.path)
+		if err != nil {
+			r.notifyReload(err)
+			continue
+		}
+		r.mu.RLock()
+		changed := !info.ModTime().Equal(r.modTime) || info.Size() != r.size
+		r.mu.RUnlock()
+		if changed {
+			r.notifyReload(r.Reload())
+		}
+	}
+}
+
+// notifyReload 回報重新載入結果
+func (r *Router) notifyReload(err error) {
+	if r.opts.OnReload != nil {
+		r.opts.OnReload(err)
+	}
+}
+
+// build 套用環境變數並建立路由表
+func (r *Router) build(cfg *Config) (*table, error) {
+	// 複製目標設定，避免修改呼叫端的 Config
+	c := *cfg
+	c.Destinations = make(map[string]DestinationConfig, len(cfg.Destinations))
+	for name, d := range cfg.Destinations {
+		c.Destinations[name] = d
+	}
+	if err := c.applyEnv(r.opts.EnvPrefix, r.opts.LookupEnv); err != nil {
+		return nil, err
+	}
+
+	t := &table{
+		destinations: make(map[string]*samhook.Destination, len(c.Destinations)),
+		routes:       c.Routes,
+		defaults:     c.Default,
+	}
+	for name, dc := range c.Destinations {
+		d, err := buildDestination(name, dc)
+		if err != nil {
+			return nil, err
+		}
+		t.destinations[name] = d
+	}
+
+	check := func(where string, names []string) error {
+		for _, name := range names {
+			if _, ok := t.destinations[name]; !ok {
+				return fmt.Errorf("%s: unknown destination %q", where, name)
+			}
+		}
+		return nil
+	}
+	for i, route := range c.Routes {
+		if err := check(fmt.Sprintf("route %d (%s)", i, route.Name), route.Destinations); err != nil {
+			return nil, err
+		}
+	}
+	if err := check("default", c.Default); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// matches 判斷 key 是否符合所有非空條件
+func (m Match) matches(key RouteKey) bool {
+	if len(m.Severity) > 0 && !slices.ContainsFunc(m.Severity, func(s string) bool {
+		return strings.EqualFold(s, key.Severity)
+	}) {
+		return false
+	}
+	if len(m.Tags) > 0 && !slices.ContainsFunc(m.Tags, func(tag string) bool {
+		return slices.Contains(key.Tags, tag)
+	}) {
+		return false
+	}
+	if len(m.Source) > 0 && !slices.ContainsFunc(m.Source, func(pattern string) bool {
+		ok, err := path.Match(pattern, key.Source)
+		return err == nil && ok
+	}) {
+		return false
+	}
+	return true
+}
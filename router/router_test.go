@@ -0,0 +1,198 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/circleyu/samhook"
+)
+
+// hitCounter 記錄各路徑收到的請求數
+type hitCounter struct {
+	mu   sync.Mutex
+	hits map[string]int
+}
+
+func (c *hitCounter) get(path string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits[path]
+}
+
+// newTestServer 創建依路徑計數的 mock webhook，/fail 返回 500
+func newTestServer(t *testing.T) (*httptest.Server, *hitCounter) {
+	counter := &hitCounter{hits: make(map[string]int)}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		counter.mu.Lock()
+		counter.hits[r.URL.Path]++
+		counter.mu.Unlock()
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return server, counter
+}
+
+func testConfig(base string) *Config {
+	return &Config{
+		Destinations: map[string]DestinationConfig{
+			"oncall": {URL: base + "/oncall"},
+			"team":   {URL: base + "/team"},
+			"db":     {URL: base + "/db"},
+			"broken": {URL: base + "/fail"},
+		},
+		Routes: []RouteConfig{
+			{Name: "critical", Match: Match{Severity: []string{"critical"}}, Destinations: []string{"oncall", "team"}, Continue: true},
+			{Name: "database", Match: Match{Tags: []string{"db"}}, Destinations: []string{"db", "team"}},
+			{Name: "batch", Match: Match{Source: []string{"batch-*"}}, Destinations: []string{"broken"}},
+		},
+		Default: []string{"team"},
+	}
+}
+
+func TestRouter_Match(t *testing.T) {
+	r, err := New(testConfig("https://example.com"), nil)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		key  RouteKey
+		want []string
+	}{
+		{"嚴重程度（不區分大小寫）並繼續比對", RouteKey{Severity: "CRITICAL", Tags: []string{"db"}}, []string{"oncall", "team", "db"}},
+		{"標籤", RouteKey{Severity: "warning", Tags: []string{"web", "db"}}, []string{"db", "team"}},
+		{"來源萬用字元", RouteKey{Source: "batch-nightly"}, []string{"broken"}},
+		{"預設目標", RouteKey{Severity: "info"}, []string{"team"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dests, err := r.Match(tt.key)
+			if err != nil {
+				t.Fatalf("Match() error = %v", err)
+			}
+			var got []string
+			for _, d := range dests {
+				got = append(got, d.Name)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRouter_NoRoute(t *testing.T) {
+	cfg := testConfig("https://example.com")
+	cfg.Default = nil
+	r, _ := New(cfg, nil)
+	if err := r.Send(context.Background(), RouteKey{}, samhook.Message{Text: "x"}); !errors.Is(err, ErrNoRoute) {
+		t.Errorf("expected ErrNoRoute, got %v", err)
+	}
+}
+
+func TestRouter_InvalidConfig(t *testing.T) {
+	cfg := testConfig("https://example.com")
+	cfg.Routes[0].Destinations = []string{"missing"}
+	if _, err := New(cfg, nil); err == nil {
+		t.Error("expected unknown destination error")
+	}
+}
+
+func TestRouter_Send(t *testing.T) {
+	server, counter := newTestServer(t)
+	r, err := New(testConfig(server.URL), nil)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if err := r.Send(context.Background(), RouteKey{Severity: "critical"}, samhook.Message{Text: "down"}); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if counter.get("/oncall") != 1 || counter.get("/team") != 1 {
+		t.Errorf("unexpected hits: %v", counter.hits)
+	}
+
+	err = r.Send(context.Background(), RouteKey{Source: "batch-1"}, samhook.Message{Text: "x"})
+	var multiErr *samhook.MultiError
+	if !errors.As(err, &multiErr) || multiErr.Errors[0].Destination != "broken" {
+		t.Errorf("expected MultiError for broken destination, got %v", err)
+	}
+	var webhookErr *samhook.WebhookError
+	if !errors.As(err, &webhookErr) || webhookErr.StatusCode != http.StatusInternalServerError {
+		t.Errorf("expected wrapped WebhookError, got %v", err)
+	}
+}
+
+func TestRouter_EnvOverride(t *testing.T) {
+	server, counter := newTestServer(t)
+	r, err := New(testConfig("https://unreachable.invalid"), &Options{
+		EnvPrefix: "TEST",
+		LookupEnv: func(key string) (string, bool) {
+			if key == "TEST_DEST_TEAM_URL" {
+				return server.URL + "/team", true
+			}
+			return "", false
+		},
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if err := r.Send(context.Background(), RouteKey{}, samhook.Message{Text: "x"}); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if counter.get("/team") != 1 {
+		t.Error("expected env override URL to be used")
+	}
+}
+
+func TestRouter_WatchReloads(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "routes.yaml")
+	write := func(dest string) {
+		data := fmt.Sprintf("destinations:\n  %s:\n    url: https://example.com/%s\ndefault: [%s]\n", dest, dest, dest)
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("first")
+
+	reloaded := make(chan error, 10)
+	r, err := NewFromFile(path, &Options{OnReload: func(err error) { reloaded <- err }})
+	if err != nil {
+		t.Fatalf("NewFromFile() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go r.Watch(ctx, 10*time.Millisecond)
+
+	write("second-destination")
+	select {
+	case err := <-reloaded:
+		if err != nil {
+			t.Fatalf("reload error = %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("config was not reloaded")
+	}
+
+	if _, ok := r.Destination("second-destination"); !ok {
+		t.Error("expected new destination after reload")
+	}
+	if _, ok := r.Destination("first"); ok {
+		t.Error("old destination should be removed after reload")
+	}
+}
@@ -2,8 +2,13 @@ package samhook
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"io"
 	"net/http"
+	"net/http/httptrace"
+	"strconv"
+	"sync/atomic"
 	"time"
 
 	"github.com/bytedance/sonic"
@@ -22,7 +27,15 @@ func (m *Message) AddAttachments(attachments []Attachment) *Message {
 }
 
 // sendRequest 內部函數，統一處理 HTTP 請求
-func sendRequest(client *http.Client, req *http.Request) error {
+//
+// 平台實作 ResponseChecker 時由平台判斷回應是否成功，否則僅接受 HTTP 200。
+func sendRequest(client *http.Client, req *http.Request, provider Provider) error {
+	_, err := doRequest(client, req, provider)
+	return err
+}
+
+// doRequest 發送請求並返回回應內容，判斷成功的方式與 sendRequest 相同
+func doRequest(client *http.Client, req *http.Request, provider Provider) ([]byte, error) {
 	start := time.Now()
 	resp, err := client.Do(req)
 	duration := time.Since(start)
@@ -33,44 +46,190 @@ func sendRequest(client *http.Client, req *http.Request) error {
 	}
 
 	if err != nil {
-		return NewNetworkError(req.URL.String(), err)
+		var blocked *BlockedAddressError
+		if errors.As(err, &blocked) {
+			return nil, NewBlockedAddressError(req.URL.String(), err)
+		}
+		return nil, NewNetworkError(req.URL.String(), err)
 	}
 	defer resp.Body.Close()
 
-	// 檢查 HTTP 狀態碼
-	if resp.StatusCode != http.StatusOK {
-		// 讀取錯誤回應體
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		responseBody := string(bodyBytes)
-		apiErr := NewAPIError(req.URL.String(), resp.StatusCode, responseBody)
+	bodyBytes, _ := io.ReadAll(resp.Body)
+	var apiErr error
+	if checker, ok := provider.(ResponseChecker); ok {
+		apiErr = checker.CheckResponse(req.URL.String(), resp.StatusCode, bodyBytes)
+	} else if resp.StatusCode != http.StatusOK {
+		apiErr = NewAPIError(req.URL.String(), resp.StatusCode, string(bodyBytes))
+	}
+
+	if apiErr != nil {
+		// 平台未提供等待時間時使用 Retry-After 標頭
+		var webhookErr *WebhookError
+		if errors.As(apiErr, &webhookErr) && webhookErr.RetryAfter == 0 {
+			webhookErr.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+		}
 
 		// 記錄 API 錯誤日誌
 		if defaultPackageLogger != nil {
 			defaultPackageLogger.LogRequest(req.URL.String(), req.Method, duration, apiErr)
 		}
+		return bodyBytes, apiErr
+	}
+
+	return bodyBytes, nil
+}
+
+// apiRequest 以 Bearer token 發送 JSON API 請求並返回回應內容
+//
+// 供需要讀取回應的 Web API 客戶端使用，payload 為 nil 時不帶請求內容。
+func apiRequest(ctx context.Context, client *http.Client, provider Provider, method, url, token string, payload any) ([]byte, error) {
+	var body io.Reader
+	if payload != nil {
+		data, err := sonic.Marshal(payload)
+		if err != nil {
+			return nil, NewSerializationError(err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, NewNetworkError(url, err)
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return doRequest(client, req, provider)
+}
+
+// apiCall Web API 客戶端的共用設定（重試、限流與 HTTP 客戶端）
+type apiCall struct {
+	provider      Provider
+	token         string
+	retry         *RetryOptions
+	limiter       *RateLimiter
+	clientOptions []ClientOption
+}
 
-		return apiErr
+// do 帶重試與限流呼叫 API，並將回應解析到 result（為 nil 時忽略回應內容）
+//
+// POST 與 PATCH 不是冪等的（例如 chat.postMessage 重送會產生重複訊息），
+// 只有在尚未取得連線（DNS 或連線失敗）時才重試網路錯誤；429 與 5xx 仍會重試。
+func (a apiCall) do(ctx context.Context, method, url string, payload, result any) error {
+	opts := RetryOptions{}
+	if a.retry != nil {
+		opts = *a.retry
 	}
+	client := newClient(a.clientOptions)
+	idempotent := method != http.MethodPost && method != http.MethodPatch
 
+	var body []byte
+	err := retry(ctx, opts, func() error {
+		if a.limiter != nil {
+			if err := a.limiter.Wait(ctx); err != nil {
+				return NewNetworkError(url, err)
+			}
+		}
+		var connected atomic.Bool
+		trace := &httptrace.ClientTrace{GotConn: func(httptrace.GotConnInfo) { connected.Store(true) }}
+		var err error
+		body, err = apiRequest(httptrace.WithClientTrace(ctx, trace), client, a.provider, method, url, a.token, payload)
+		var webhookErr *WebhookError
+		if errors.As(err, &webhookErr) && webhookErr.IsNetworkError() && !idempotent && connected.Load() {
+			// 請求可能已送達，重送會重複執行
+			return permanentError{err}
+		}
+		return err
+	})
+	var permanent permanentError
+	if errors.As(err, &permanent) {
+		return permanent.err
+	}
+	if err != nil {
+		return err
+	}
+	if result != nil && len(body) > 0 {
+		if err := sonic.Unmarshal(body, result); err != nil {
+			return NewSerializationError(err)
+		}
+	}
 	return nil
 }
 
-// Send 發送message
-func Send(url string, msg Message) error {
+// permanentError 標記不應重試的錯誤（retry 不重試非 WebhookError 的錯誤）
+type permanentError struct{ err error }
+
+func (e permanentError) Error() string { return e.err.Error() }
+
+func (e permanentError) Unwrap() error { return e.err }
+
+// encodeMessage 依平台編碼訊息，啟用自動驗證時先以同一平台驗證
+func encodeMessage(webhookURL string, provider Provider, msg Message) ([]byte, error) {
+	if autoValidate.Load() {
+		if err := validateMessage(msg, provider); err != nil {
+			return nil, err
+		}
+	}
+	if encoder, ok := provider.(Encoder); ok {
+		return encoder.Encode(webhookURL, msg)
+	}
 	payloadBytes, err := sonic.Marshal(msg)
 	if err != nil {
-		return NewSerializationError(err)
+		return nil, NewSerializationError(err)
+	}
+	return payloadBytes, nil
+}
+
+// sendMessage 編碼並發送訊息，provider 為 nil 時依 URL 偵測平台
+//
+// 平台實作 Splitter 時依序發送拆分後的每一則訊息。
+func sendMessage(ctx context.Context, client *http.Client, url string, provider Provider, msg Message) error {
+	provider = resolveProvider(url, provider)
+	for _, m := range splitMessageFor(provider, msg) {
+		if err := sendSingle(ctx, client, url, provider, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitMessageFor 依平台拆分訊息，未實作 Splitter 時返回原訊息
+func splitMessageFor(provider Provider, msg Message) []Message {
+	if splitter, ok := provider.(Splitter); ok {
+		return splitter.Split(msg)
+	}
+	return []Message{msg}
+}
+
+// sendSingle 編碼並發送單一則訊息
+func sendSingle(ctx context.Context, client *http.Client, url string, provider Provider, msg Message) error {
+	payloadBytes, err := encodeMessage(url, provider, msg)
+	if err != nil {
+		return err
 	}
 	body := bytes.NewReader(payloadBytes)
 
-	req, err := http.NewRequest(http.MethodPost, url, body)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
 	if err != nil {
 		return NewNetworkError(url, err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	if modifier, ok := provider.(RequestModifier); ok {
+		if err := modifier.ModifyRequest(req); err != nil {
+			return err
+		}
+	}
 
-	return sendRequest(http.DefaultClient, req)
+	return sendRequest(client, req, provider)
+}
+
+// Send 發送message
+func Send(url string, msg Message) error {
+	return sendMessage(context.Background(), http.DefaultClient, url, nil, msg)
 }
 
 // SendReader 發送message
@@ -82,5 +241,22 @@ func SendReader(url string, r io.Reader) error {
 
 	req.Header.Set("Content-Type", "application/json")
 
-	return sendRequest(http.DefaultClient, req)
+	provider, _ := DetectProvider(url)
+	return sendRequest(http.DefaultClient, req, provider)
+}
+
+// parseRetryAfter 解析 Retry-After 標頭（秒數或 HTTP 日期），無法解析時返回 0
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
 }
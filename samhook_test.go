@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/bytedance/sonic"
@@ -28,6 +29,36 @@ func createTestMessage() Message {
 	}
 }
 
+// decodeTestMessage 解析請求中的 Message
+func decodeTestMessage(r *http.Request, msg *Message) error {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	return sonic.Unmarshal(body, msg)
+}
+
+// collectMessages 創建收集收到訊息的 mock 伺服器，返回 URL 與讀取函數
+func collectMessages(t *testing.T) (string, func() []Message) {
+	var mu sync.Mutex
+	var received []Message
+	server := mockWebhookServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var msg Message
+		if err := decodeTestMessage(r, &msg); err != nil {
+			t.Errorf("invalid JSON: %v", err)
+		}
+		mu.Lock()
+		received = append(received, msg)
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	})
+	return server.URL, func() []Message {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]Message(nil), received...)
+	}
+}
+
 // createTestAttachment 創建測試用的 Attachment
 func createTestAttachment() Attachment {
 	return Attachment{
@@ -0,0 +1,145 @@
+package samhook
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severity 訊息的嚴重程度，對應一致的顏色、emoji 與各平台的樣式
+type Severity string
+
+// 嚴重程度（由低至高，SeverityResolved 表示問題已解除）
+const (
+	SeverityDebug    Severity = "debug"
+	SeverityInfo     Severity = "info"
+	SeverityNotice   Severity = "notice"
+	SeverityWarning  Severity = "warning"
+	SeverityError    Severity = "error"
+	SeverityCritical Severity = "critical"
+	SeverityResolved Severity = "resolved"
+)
+
+// severityStyle 嚴重程度在各平台的樣式
+type severityStyle struct {
+	label      string
+	color      string
+	emoji      string
+	teamsStyle string
+	chatIcon   string
+}
+
+// severityStyles 嚴重程度的調色盤（warning、error、resolved 與 Warning、Danger、Good 相同）
+var severityStyles = map[Severity]severityStyle{
+	SeverityDebug:    {label: "Debug", color: "#9E9E9E", emoji: "🔍", teamsStyle: "default", chatIcon: "bug_report"},
+	SeverityInfo:     {label: "Info", color: "#2196F3", emoji: "ℹ️", teamsStyle: "accent", chatIcon: "info"},
+	SeverityNotice:   {label: "Notice", color: "#00BCD4", emoji: "📣", teamsStyle: "emphasis", chatIcon: "campaign"},
+	SeverityWarning:  {label: "Warning", color: Warning, emoji: "⚠️", teamsStyle: "warning", chatIcon: "warning"},
+	SeverityError:    {label: "Error", color: Danger, emoji: "❌", teamsStyle: "attention", chatIcon: "error"},
+	SeverityCritical: {label: "Critical", color: "#8B0000", emoji: "🚨", teamsStyle: "attention", chatIcon: "report"},
+	SeverityResolved: {label: "Resolved", color: Good, emoji: "✅", teamsStyle: "good", chatIcon: "check_circle"},
+}
+
+// severityAliases ParseSeverity 接受的別名（例如 syslog 與 Alertmanager 常用的名稱）
+var severityAliases = map[string]Severity{
+	"trace":     SeverityDebug,
+	"ok":        SeverityResolved,
+	"good":      SeverityResolved,
+	"warn":      SeverityWarning,
+	"err":       SeverityError,
+	"danger":    SeverityError,
+	"crit":      SeverityCritical,
+	"fatal":     SeverityCritical,
+	"emerg":     SeverityCritical,
+	"emergency": SeverityCritical,
+	"alert":     SeverityCritical,
+	"page":      SeverityCritical,
+}
+
+// ParseSeverity 解析嚴重程度（不分大小寫，接受 warn、crit、fatal 等別名）
+func ParseSeverity(s string) (Severity, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if sev := Severity(s); sev.Valid() {
+		return sev, nil
+	}
+	if sev, ok := severityAliases[s]; ok {
+		return sev, nil
+	}
+	return "", fmt.Errorf("unknown severity %q", s)
+}
+
+// Valid 判斷是否為已定義的嚴重程度
+func (s Severity) Valid() bool {
+	_, ok := severityStyles[s]
+	return ok
+}
+
+// String 返回嚴重程度的名稱
+func (s Severity) String() string {
+	return string(s)
+}
+
+// Label 返回顯示用的名稱（例如 "Warning"）
+func (s Severity) Label() string {
+	return severityStyles[s].label
+}
+
+// Color 返回 attachment 使用的十六進位顏色
+func (s Severity) Color() string {
+	return severityStyles[s].color
+}
+
+// Emoji 返回代表嚴重程度的 Unicode emoji（所有平台都能顯示）
+func (s Severity) Emoji() string {
+	return severityStyles[s].emoji
+}
+
+// TeamsStyle 返回 Adaptive Card Container 的樣式
+func (s Severity) TeamsStyle() string {
+	return severityStyles[s].teamsStyle
+}
+
+// DiscordColor 返回 Discord embed 的整數顏色
+func (s Severity) DiscordColor() int {
+	color, _ := colorValue(s.Color())
+	return color
+}
+
+// GoogleChatIcon 返回 Google Chat 卡片使用的 Material 圖示名稱
+func (s Severity) GoogleChatIcon() string {
+	return severityStyles[s].chatIcon
+}
+
+// severityStyled 判斷 attachment 的顏色樣式是否依嚴重程度決定
+//
+// Color 為空或等於嚴重程度的顏色時使用嚴重程度的樣式；之後另外設置了
+// 不同的 Color 時以 Color 為準。
+func severityStyled(a Attachment) bool {
+	return a.Severity.Valid() && (a.Color == "" || a.Color == a.Severity.Color())
+}
+
+// AlertAttachment 建立依嚴重程度設置顏色與 emoji 的 attachment
+func AlertAttachment(sev Severity, title, body string) Attachment {
+	heading := title
+	if emoji := sev.Emoji(); emoji != "" {
+		heading = emoji + " " + title
+	}
+	fallback := title
+	if label := sev.Label(); label != "" {
+		fallback = "[" + strings.ToUpper(label) + "] " + title
+	}
+	return Attachment{
+		Fallback: fallback,
+		Color:    sev.Color(),
+		Title:    heading,
+		Text:     body,
+		MrkdwnIn: []string{"text"},
+		Severity: sev,
+	}
+}
+
+// Alert 建立只包含一個告警 attachment 的訊息，適用於所有平台
+//
+//	samhook.Send(url, samhook.Alert(samhook.SeverityError, "Deploy failed", "api: exit status 1"))
+func Alert(sev Severity, title, body string) Message {
+	return Message{Attachments: []Attachment{AlertAttachment(sev, title, body)}}
+}
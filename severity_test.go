@@ -0,0 +1,151 @@
+package samhook
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bytedance/sonic"
+)
+
+func TestParseSeverity(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Severity
+		wantErr bool
+	}{
+		{name: "標準名稱", input: "warning", want: SeverityWarning},
+		{name: "不分大小寫", input: " Critical ", want: SeverityCritical},
+		{name: "別名 warn", input: "WARN", want: SeverityWarning},
+		{name: "別名 fatal", input: "fatal", want: SeverityCritical},
+		{name: "別名 ok", input: "ok", want: SeverityResolved},
+		{name: "未知的名稱", input: "urgent", wantErr: true},
+		{name: "空字串", input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSeverity(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSeverity() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseSeverity() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeverity_Styles(t *testing.T) {
+	all := []Severity{SeverityDebug, SeverityInfo, SeverityNotice, SeverityWarning, SeverityError, SeverityCritical, SeverityResolved}
+	for _, sev := range all {
+		t.Run(sev.String(), func(t *testing.T) {
+			if !sev.Valid() || sev.Label() == "" || sev.Emoji() == "" || sev.TeamsStyle() == "" || sev.GoogleChatIcon() == "" {
+				t.Errorf("incomplete style for %q", sev)
+			}
+			if !isValidColor(sev.Color()) {
+				t.Errorf("invalid color %q", sev.Color())
+			}
+			if want, _ := colorValue(sev.Color()); sev.DiscordColor() != want {
+				t.Errorf("DiscordColor() = %d, want %d", sev.DiscordColor(), want)
+			}
+		})
+	}
+
+	// 與既有的顏色常數一致
+	if SeverityWarning.Color() != Warning || SeverityError.Color() != Danger || SeverityResolved.Color() != Good {
+		t.Error("severity palette does not match Warning/Danger/Good")
+	}
+	if Severity("urgent").Valid() || Severity("urgent").Color() != "" {
+		t.Error("unknown severity should have no style")
+	}
+}
+
+func TestAlert(t *testing.T) {
+	msg := Alert(SeverityCritical, "Database down", "primary is *unreachable*")
+	if problems := msg.Validate(SlackProvider{}); problems != nil {
+		t.Fatalf("Validate() = %v", problems)
+	}
+	a := msg.Attachments[0]
+	if a.Title != "🚨 Database down" || a.Fallback != "[CRITICAL] Database down" || a.Color != "#8B0000" {
+		t.Errorf("unexpected attachment: %+v", a)
+	}
+
+	// Slack 的 JSON 不包含 severity
+	data, _ := sonic.Marshal(msg)
+	if strings.Contains(string(data), "severity") {
+		t.Errorf("severity leaked into payload: %s", data)
+	}
+}
+
+func TestAlert_Providers(t *testing.T) {
+	msg := Alert(SeverityNotice, "Maintenance", "starts at 22:00")
+
+	tests := []struct {
+		name     string
+		provider Encoder
+		want     string
+	}{
+		{name: "Teams container 樣式", provider: TeamsProvider{}, want: `"style":"emphasis"`},
+		{name: "Discord 整數顏色", provider: DiscordProvider{}, want: `"color":48340`},
+		{name: "Google Chat 圖示", provider: GoogleChatProvider{}, want: `"materialIcon":{"name":"campaign"}`},
+		{name: "Telegram 不重複 emoji", provider: TelegramProvider{ChatID: "1"}, want: `📣 Maintenance`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.provider.Encode("", msg)
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if !strings.Contains(string(data), tt.want) {
+				t.Errorf("Encode() = %s, want substring %s", data, tt.want)
+			}
+			if strings.Count(string(data), "📣") > 1 {
+				t.Errorf("emoji repeated: %s", data)
+			}
+		})
+	}
+}
+
+func TestSeverity_ColorOverride(t *testing.T) {
+	tests := []struct {
+		name       string
+		attachment Attachment
+		discord    string
+		teams      string
+	}{
+		{
+			name:       "Severity 之後設置 Color",
+			attachment: NewAttachment().Title("deploy", "").Severity(SeverityCritical).Color(Good).Build(),
+			discord:    `"color":65280`,
+			teams:      `"style":"good"`,
+		},
+		{
+			name:       "Color 之後設置 Severity",
+			attachment: NewAttachment().Title("deploy", "").Color(Good).Severity(SeverityCritical).Build(),
+			discord:    `"color":9109504`,
+			teams:      `"style":"attention"`,
+		},
+		{
+			name:       "struct 同時設置不同的 Color",
+			attachment: Attachment{Fallback: "deploy", Title: "deploy", Color: Good, Severity: SeverityCritical},
+			discord:    `"color":65280`,
+			teams:      `"style":"good"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := Message{Attachments: []Attachment{tt.attachment}}
+			data, _ := DiscordProvider{}.Encode("", msg)
+			if !strings.Contains(string(data), tt.discord) {
+				t.Errorf("Discord = %s, want substring %s", data, tt.discord)
+			}
+			data, _ = TeamsProvider{}.Encode("", msg)
+			if !strings.Contains(string(data), tt.teams) {
+				t.Errorf("Teams = %s, want substring %s", data, tt.teams)
+			}
+		})
+	}
+}
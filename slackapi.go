@@ -0,0 +1,153 @@
+package samhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/bytedance/sonic"
+)
+
+// DefaultSlackAPIURL Slack Web API 的預設位址
+const DefaultSlackAPIURL = "https://slack.com/api"
+
+// MessageRef 已發送訊息的參照（頻道與時間戳記），用於更新、刪除與回覆
+type MessageRef struct {
+	Channel string `json:"channel"`
+	TS      string `json:"ts"`
+}
+
+// SlackClient Slack Web API 客戶端（使用 bot token）
+//
+// 與 incoming webhook 不同，Web API 會返回訊息的 ts，可用於討論串與後續
+// 操作。重試、限流與日誌記錄與 webhook 發送共用相同的機制。
+type SlackClient struct {
+	// Token bot token（xoxb-...）
+	Token string
+
+	// BaseURL API 位址，為空時使用 DefaultSlackAPIURL（可指向本地的測試伺服器）
+	BaseURL string
+
+	// Retry 重試選項，為 nil 時不重試
+	Retry *RetryOptions
+
+	// RateLimiter 每次呼叫前等待的限流器，為 nil 時不限流
+	RateLimiter *RateLimiter
+
+	// ClientOptions HTTP 客戶端選項
+	ClientOptions []ClientOption
+}
+
+// slackAPIResponse Web API 的回應格式
+type slackAPIResponse struct {
+	OK      bool   `json:"ok"`
+	Error   string `json:"error"`
+	Channel string `json:"channel"`
+	TS      string `json:"ts"`
+}
+
+// slackAPI 判斷 Web API 回應的平台（Slack 失敗時通常仍返回 HTTP 200）
+type slackAPI struct{ SlackProvider }
+
+// CheckResponse 解析 {"ok":false,"error":..} 回應
+func (slackAPI) CheckResponse(webhookURL string, statusCode int, body []byte) error {
+	var resp slackAPIResponse
+	if err := sonic.Unmarshal(body, &resp); err != nil {
+		return NewAPIError(webhookURL, statusCode, string(body))
+	}
+	if resp.OK && statusCode == http.StatusOK {
+		return nil
+	}
+	apiErr := NewProviderError(webhookURL, statusCode, string(body), resp.Error, "")
+	apiErr.ErrorCode = slackErrorCode(resp.Error)
+	return apiErr
+}
+
+// slackErrorCode 將 Slack 的錯誤字串對應為錯誤代碼
+func slackErrorCode(code string) string {
+	switch code {
+	case "ratelimited", "rate_limited":
+		return ErrorCodeAPIRateLimit
+	case "not_authed", "invalid_auth", "account_inactive", "token_revoked", "token_expired":
+		return ErrorCodeAPIUnauthorized
+	case "missing_scope", "not_in_channel", "is_archived", "restricted_action", "cant_update_message", "cant_delete_message":
+		return ErrorCodeAPIForbidden
+	case "channel_not_found", "message_not_found", "thread_not_found":
+		return ErrorCodeAPINotFound
+	case "no_text", "msg_too_long", "too_many_attachments", "invalid_blocks", "invalid_attachments", "invalid_arguments":
+		return ErrorCodeInvalidMessage
+	case "internal_error", "fatal_error", "service_unavailable", "request_timeout":
+		return ErrorCodeAPIServerError
+	}
+	return ""
+}
+
+// PostMessage 以 chat.postMessage 發送訊息，msg.Channel 為目標頻道
+func (c *SlackClient) PostMessage(ctx context.Context, msg Message) (MessageRef, error) {
+	if msg.Channel == "" {
+		return MessageRef{}, NewValidationError(ErrorCodeInvalidMessage, errors.New("channel is required"))
+	}
+	if autoValidate.Load() {
+		if err := validateMessage(msg, SlackProvider{}); err != nil {
+			return MessageRef{}, err
+		}
+	}
+
+	var resp slackAPIResponse
+	if err := c.call(ctx, "chat.postMessage", msg, &resp); err != nil {
+		return MessageRef{}, err
+	}
+	return MessageRef{Channel: resp.Channel, TS: resp.TS}, nil
+}
+
+// Reply 在 ref 的討論串中回覆訊息，msg.ReplyBroadcast 為 true 時同時發送到頻道
+func (c *SlackClient) Reply(ctx context.Context, ref MessageRef, msg Message) (MessageRef, error) {
+	msg.Channel = ref.Channel
+	msg.ThreadTS = ref.TS
+	return c.PostMessage(ctx, msg)
+}
+
+// slackUpdatePayload chat.update 的請求內容
+type slackUpdatePayload struct {
+	Message
+	TS string `json:"ts"`
+}
+
+// Update 以 chat.update 將 ref 的內容替換為 msg（文字與 attachment）
+func (c *SlackClient) Update(ctx context.Context, ref MessageRef, msg Message) (MessageRef, error) {
+	if ref.Channel == "" || ref.TS == "" {
+		return MessageRef{}, NewValidationError(ErrorCodeInvalidMessage, errors.New("message ref requires channel and ts"))
+	}
+	msg.Channel = ref.Channel
+
+	var resp slackAPIResponse
+	if err := c.call(ctx, "chat.update", slackUpdatePayload{Message: msg, TS: ref.TS}, &resp); err != nil {
+		return MessageRef{}, err
+	}
+	return MessageRef{Channel: resp.Channel, TS: resp.TS}, nil
+}
+
+// Delete 以 chat.delete 刪除 ref 的訊息
+func (c *SlackClient) Delete(ctx context.Context, ref MessageRef) error {
+	if ref.Channel == "" || ref.TS == "" {
+		return NewValidationError(ErrorCodeInvalidMessage, errors.New("message ref requires channel and ts"))
+	}
+	return c.call(ctx, "chat.delete", ref, nil)
+}
+
+// call 呼叫 Web API 方法並將回應解析到 result
+func (c *SlackClient) call(ctx context.Context, method string, payload, result any) error {
+	baseURL := c.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultSlackAPIURL
+	}
+	api := apiCall{
+		provider:      slackAPI{},
+		token:         c.Token,
+		retry:         c.Retry,
+		limiter:       c.RateLimiter,
+		clientOptions: c.ClientOptions,
+	}
+	return api.do(ctx, http.MethodPost, strings.TrimSuffix(baseURL, "/")+"/"+method, payload, result)
+}
@@ -0,0 +1,189 @@
+package samhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bytedance/sonic"
+)
+
+func TestSlackClient_PostMessage(t *testing.T) {
+	var got Message
+	server := mockWebhookServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/chat.postMessage" || r.Header.Get("Authorization") != "Bearer xoxb-test" {
+			t.Errorf("unexpected request: %s %s", r.URL.Path, r.Header.Get("Authorization"))
+		}
+		decodeTestMessage(r, &got)
+		w.Write([]byte(`{"ok":true,"channel":"C123","ts":"1503435956.000247"}`))
+	})
+
+	client := &SlackClient{Token: "xoxb-test", BaseURL: server.URL}
+	msg := createTestMessage()
+	msg.Channel = "#general"
+	ref, err := client.PostMessage(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("PostMessage() error = %v", err)
+	}
+	if ref != (MessageRef{Channel: "C123", TS: "1503435956.000247"}) {
+		t.Errorf("unexpected ref: %+v", ref)
+	}
+	if got.Channel != "#general" || got.Text != msg.Text {
+		t.Errorf("unexpected payload: %+v", got)
+	}
+
+	if _, err := client.PostMessage(context.Background(), createTestMessage()); err == nil {
+		t.Error("expected error without channel")
+	}
+}
+
+func TestSlackClient_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		body      string
+		wantCode  string
+		retryable bool
+	}{
+		{name: "找不到頻道", status: http.StatusOK, body: `{"ok":false,"error":"channel_not_found"}`, wantCode: ErrorCodeAPINotFound},
+		{name: "無效的 token", status: http.StatusOK, body: `{"ok":false,"error":"invalid_auth"}`, wantCode: ErrorCodeAPIUnauthorized},
+		{name: "限流", status: http.StatusTooManyRequests, body: `{"ok":false,"error":"ratelimited"}`, wantCode: ErrorCodeAPIRateLimit, retryable: true},
+		{name: "內部錯誤", status: http.StatusOK, body: `{"ok":false,"error":"internal_error"}`, wantCode: ErrorCodeAPIServerError, retryable: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := slackAPI{}.CheckResponse("u", tt.status, []byte(tt.body))
+			var webhookErr *WebhookError
+			if !errors.As(err, &webhookErr) {
+				t.Fatalf("expected *WebhookError, got %v", err)
+			}
+			if webhookErr.GetErrorCode() != tt.wantCode || isRetryable(webhookErr) != tt.retryable {
+				t.Errorf("code = %s, retryable = %v", webhookErr.GetErrorCode(), isRetryable(webhookErr))
+			}
+		})
+	}
+}
+
+func TestSlackClient_Retry(t *testing.T) {
+	attempts := 0
+	server := mockWebhookServer(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"ok":false,"error":"ratelimited"}`))
+			return
+		}
+		data, _ := sonic.Marshal(slackAPIResponse{OK: true, Channel: "C1", TS: "1.2"})
+		w.Write(data)
+	})
+
+	client := &SlackClient{
+		Token:   "xoxb-test",
+		BaseURL: server.URL,
+		Retry:   &RetryOptions{MaxRetries: 1, Interval: time.Millisecond},
+	}
+	start := time.Now()
+	ref, err := client.PostMessage(context.Background(), Message{Channel: "C1", Text: "hi"})
+	if err != nil {
+		t.Fatalf("PostMessage() error = %v", err)
+	}
+	if ref.TS != "1.2" || attempts != 2 {
+		t.Errorf("ref = %+v, attempts = %d", ref, attempts)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("expected Retry-After to be honored, retried after %v", elapsed)
+	}
+}
+
+func TestSlackClient_NoRetryAfterSend(t *testing.T) {
+	// 伺服器讀取請求後直接關閉連線：訊息可能已經送出，不應重送
+	var attempts atomic.Int32
+	server := mockWebhookServer(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err == nil {
+			conn.Close()
+		}
+	})
+
+	client := &SlackClient{
+		Token:   "xoxb-test",
+		BaseURL: server.URL,
+		Retry:   &RetryOptions{MaxRetries: 2, Interval: time.Millisecond},
+	}
+	_, err := client.PostMessage(context.Background(), Message{Channel: "C1", Text: "hi"})
+	var webhookErr *WebhookError
+	if !errors.As(err, &webhookErr) || !webhookErr.IsNetworkError() {
+		t.Fatalf("expected network error, got %v", err)
+	}
+	if n := attempts.Load(); n != 1 {
+		t.Errorf("expected 1 attempt, got %d", n)
+	}
+
+	// 尚未建立連線的錯誤可以安全重試
+	url := server.URL
+	server.Close()
+	client.BaseURL = url
+	start := time.Now()
+	client.Retry = &RetryOptions{MaxRetries: 2, Interval: 50 * time.Millisecond}
+	if _, err := client.PostMessage(context.Background(), Message{Channel: "C1", Text: "hi"}); err == nil {
+		t.Fatal("expected error from closed server")
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("expected connection failures to be retried, returned after %v", elapsed)
+	}
+}
+
+func TestSlackClient_UpdateDeleteReply(t *testing.T) {
+	var calls []string
+	var payloads []map[string]any
+	server := mockWebhookServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]any
+		sonic.ConfigDefault.NewDecoder(r.Body).Decode(&payload)
+		calls = append(calls, r.URL.Path)
+		payloads = append(payloads, payload)
+		w.Write([]byte(`{"ok":true,"channel":"C1","ts":"2.0"}`))
+	})
+
+	client := &SlackClient{Token: "xoxb-test", BaseURL: server.URL}
+	ref := MessageRef{Channel: "C1", TS: "1.0"}
+	ctx := context.Background()
+
+	if _, err := client.Update(ctx, ref, Message{Text: "部署中 (2/3)"}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if _, err := client.Reply(ctx, ref, Message{Text: "詳細記錄", ReplyBroadcast: true}); err != nil {
+		t.Fatalf("Reply() error = %v", err)
+	}
+	if err := client.Delete(ctx, ref); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	want := []string{"/chat.update", "/chat.postMessage", "/chat.delete"}
+	for i, path := range want {
+		if i >= len(calls) || calls[i] != path {
+			t.Fatalf("calls = %v, want %v", calls, want)
+		}
+		if payloads[i]["channel"] != "C1" {
+			t.Errorf("%s: channel = %v", path, payloads[i]["channel"])
+		}
+	}
+	if payloads[0]["ts"] != "1.0" || payloads[0]["text"] != "部署中 (2/3)" {
+		t.Errorf("unexpected update payload: %v", payloads[0])
+	}
+	if payloads[1]["thread_ts"] != "1.0" || payloads[1]["reply_broadcast"] != true {
+		t.Errorf("unexpected reply payload: %v", payloads[1])
+	}
+	if payloads[2]["ts"] != "1.0" {
+		t.Errorf("unexpected delete payload: %v", payloads[2])
+	}
+
+	if err := client.Delete(ctx, MessageRef{Channel: "C1"}); err == nil {
+		t.Error("expected error for ref without ts")
+	}
+}
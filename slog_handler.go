@@ -0,0 +1,290 @@
+package samhook
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultSlogQueueSize 預設的非同步發送佇列大小
+const DefaultSlogQueueSize = 100
+
+// SlogHandlerOptions slog Handler 選項
+type SlogHandlerOptions struct {
+	// Level 最低轉發等級，為 nil 時使用 slog.LevelError
+	Level slog.Leveler
+
+	// Username、IconEmoji、Channel 訊息預設值
+	Username  string
+	IconEmoji string
+	Channel   string
+
+	// AddSource 是否附上呼叫位置
+	AddSource bool
+
+	// SampleRate 取樣比例（0 到 1 之間），0 表示不取樣，全部發送
+	SampleRate float64
+
+	// DedupWindow 相同鍵值在此時間內只發送一次，0 表示不去重
+	DedupWindow time.Duration
+
+	// DedupKey 計算去重鍵值，為 nil 時使用等級加訊息內容
+	DedupKey func(r slog.Record) string
+
+	// QueueSize 非同步發送佇列大小，佇列已滿時丟棄記錄
+	QueueSize int
+
+	// Retry 發送失敗時的重試選項，為 nil 時不重試
+	Retry *RetryOptions
+
+	// ClientOptions HTTP 客戶端選項
+	ClientOptions []ClientOption
+
+	// OnError 發送失敗或記錄因佇列已滿被丟棄時呼叫
+	OnError func(err error)
+}
+
+// SlogHandler 將 log/slog 記錄轉發到 webhook 的 Handler
+//
+// 記錄會轉換為 Message 後放入佇列，由背景 goroutine 發送，
+// 呼叫端永遠不會因為網路請求而阻塞。
+type SlogHandler struct {
+	core   *slogCore
+	fields []Field
+	group  string
+}
+
+// slogCore 由同一個 Handler 衍生出的所有 Handler 共用的狀態
+type slogCore struct {
+	url   string
+	opts  SlogHandlerOptions
+	queue chan Message
+	done  chan struct{}
+
+	mu     sync.Mutex
+	closed bool
+	seen   map[string]time.Time
+}
+
+// NewSlogHandler 創建轉發到指定 webhook 的 slog Handler
+func NewSlogHandler(url string, opts *SlogHandlerOptions) *SlogHandler {
+	core := &slogCore{
+		url:  url,
+		seen: make(map[string]time.Time),
+		done: make(chan struct{}),
+	}
+	if opts != nil {
+		core.opts = *opts
+	}
+	if core.opts.Level == nil {
+		core.opts.Level = slog.LevelError
+	}
+	if core.opts.QueueSize <= 0 {
+		core.opts.QueueSize = DefaultSlogQueueSize
+	}
+	core.queue = make(chan Message, core.opts.QueueSize)
+
+	go core.run()
+
+	return &SlogHandler{core: core}
+}
+
+// Enabled 判斷等級是否達到轉發門檻
+func (h *SlogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.core.opts.Level.Level()
+}
+
+// Handle 將記錄轉換為 Message 放入發送佇列
+func (h *SlogHandler) Handle(_ context.Context, r slog.Record) error {
+	c := h.core
+	if c.opts.SampleRate > 0 && c.opts.SampleRate < 1 && rand.Float64() >= c.opts.SampleRate {
+		return nil
+	}
+
+	msg := h.buildMessage(r)
+
+	c.mu.Lock()
+	if c.closed || (c.opts.DedupWindow > 0 && c.isDuplicate(r)) {
+		c.mu.Unlock()
+		return nil
+	}
+	select {
+	case c.queue <- msg:
+		c.mu.Unlock()
+		return nil
+	default:
+	}
+	c.mu.Unlock()
+
+	c.reportError(fmt.Errorf("slog handler queue is full, dropping record: %s", r.Message))
+	return nil
+}
+
+// WithAttrs 返回帶有額外屬性的 Handler
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	h2 := *h
+	h2.fields = append(h.fields[:len(h.fields):len(h.fields)], attrsToFields(h.group, attrs)...)
+	return &h2
+}
+
+// WithGroup 返回將後續屬性放入群組的 Handler（群組以 "." 展平）
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.group = joinGroup(h.group, name)
+	return &h2
+}
+
+// Close 停止接收新記錄，等待佇列中的訊息發送完成
+func (h *SlogHandler) Close() error {
+	c := h.core
+	c.mu.Lock()
+	if !c.closed {
+		c.closed = true
+		close(c.queue)
+	}
+	c.mu.Unlock()
+
+	<-c.done
+	return nil
+}
+
+// buildMessage 將記錄轉換為 Message
+func (h *SlogHandler) buildMessage(r slog.Record) Message {
+	opts := h.core.opts
+
+	fields := append([]Field(nil), h.fields...)
+	r.Attrs(func(a slog.Attr) bool {
+		fields = append(fields, attrsToFields(h.group, []slog.Attr{a})...)
+		return true
+	})
+	if opts.AddSource && r.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+		fields = append(fields, Field{
+			Title: slog.SourceKey,
+			Value: fmt.Sprintf("%s:%d", frame.File, frame.Line),
+		})
+	}
+
+	footer := r.Level.String()
+	if !r.Time.IsZero() {
+		footer += " | " + r.Time.Format(time.RFC3339)
+	}
+
+	return Message{
+		Username:  opts.Username,
+		IconEmoji: opts.IconEmoji,
+		Channel:   opts.Channel,
+		Attachments: []Attachment{
+			{
+				Fallback: fmt.Sprintf("[%s] %s", r.Level, r.Message),
+				Color:    levelColor(r.Level),
+				Title:    r.Message,
+				Fields:   fields,
+				Footer:   footer,
+			},
+		},
+	}
+}
+
+// run 背景發送佇列中的訊息
+func (c *slogCore) run() {
+	defer close(c.done)
+	for msg := range c.queue {
+		var err error
+		if c.opts.Retry != nil {
+			err = SendWithRetry(c.url, msg, *c.opts.Retry, c.opts.ClientOptions...)
+		} else {
+			err = SendWithOptions(c.url, msg, c.opts.ClientOptions...)
+		}
+		if err != nil {
+			c.reportError(err)
+		}
+	}
+}
+
+// isDuplicate 檢查去重視窗內是否已發送過相同鍵值（呼叫時需持有鎖）
+func (c *slogCore) isDuplicate(r slog.Record) bool {
+	key := r.Level.String() + "|" + r.Message
+	if c.opts.DedupKey != nil {
+		key = c.opts.DedupKey(r)
+	}
+
+	now := time.Now()
+	if last, ok := c.seen[key]; ok && now.Sub(last) < c.opts.DedupWindow {
+		return true
+	}
+	c.seen[key] = now
+
+	// 清理過期的鍵值，避免無限增長
+	if len(c.seen) > c.opts.QueueSize*10 {
+		for k, t := range c.seen {
+			if now.Sub(t) >= c.opts.DedupWindow {
+				delete(c.seen, k)
+			}
+		}
+	}
+	return false
+}
+
+// reportError 回報錯誤
+func (c *slogCore) reportError(err error) {
+	if c.opts.OnError != nil {
+		c.opts.OnError(err)
+	}
+}
+
+// levelColor 將 slog 等級對應到 attachment 顏色
+func levelColor(level slog.Level) string {
+	switch {
+	case level >= slog.LevelError:
+		return Danger
+	case level >= slog.LevelWarn:
+		return Warning
+	default:
+		return Good
+	}
+}
+
+// attrsToFields 將屬性展平為 Field，群組以 "." 連接
+func attrsToFields(group string, attrs []slog.Attr) []Field {
+	var fields []Field
+	for _, a := range attrs {
+		a.Value = a.Value.Resolve()
+		if a.Equal(slog.Attr{}) {
+			continue
+		}
+		if a.Value.Kind() == slog.KindGroup {
+			fields = append(fields, attrsToFields(joinGroup(group, a.Key), a.Value.Group())...)
+			continue
+		}
+		value := a.Value.String()
+		fields = append(fields, Field{
+			Title: joinGroup(group, a.Key),
+			Value: value,
+			Short: len(value) <= 40 && !strings.Contains(value, "\n"),
+		})
+	}
+	return fields
+}
+
+// joinGroup 組合群組名稱
+func joinGroup(group, key string) string {
+	if group == "" {
+		return key
+	}
+	if key == "" {
+		return group
+	}
+	return group + "." + key
+}
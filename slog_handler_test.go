@@ -0,0 +1,142 @@
+package samhook
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSlogHandler_LevelThreshold(t *testing.T) {
+	url, received := collectMessages(t)
+	handler := NewSlogHandler(url, nil)
+	logger := slog.New(handler)
+
+	logger.Info("ignored")
+	logger.Warn("ignored too")
+	logger.Error("boom", "host", "web-1")
+	handler.Close()
+
+	msgs := received()
+	if len(msgs) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(msgs))
+	}
+	a := msgs[0].Attachments[0]
+	if a.Title != "boom" || a.Color != Danger {
+		t.Errorf("unexpected attachment: %+v", a)
+	}
+	if len(a.Fields) != 1 || a.Fields[0].Title != "host" || a.Fields[0].Value != "web-1" {
+		t.Errorf("unexpected fields: %+v", a.Fields)
+	}
+}
+
+func TestSlogHandler_AttrsAndGroups(t *testing.T) {
+	url, received := collectMessages(t)
+	handler := NewSlogHandler(url, &SlogHandlerOptions{Level: slog.LevelWarn, Username: "logger"})
+	logger := slog.New(handler).With("service", "api").WithGroup("req")
+
+	logger.Warn("slow request",
+		"path", "/users",
+		slog.Group("timing", "ms", 1500),
+	)
+	handler.Close()
+
+	msgs := received()
+	if len(msgs) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(msgs))
+	}
+	if msgs[0].Username != "logger" {
+		t.Errorf("expected username logger, got %q", msgs[0].Username)
+	}
+	a := msgs[0].Attachments[0]
+	if a.Color != Warning {
+		t.Errorf("expected warning color, got %s", a.Color)
+	}
+
+	want := map[string]string{
+		"service":       "api",
+		"req.path":      "/users",
+		"req.timing.ms": "1500",
+	}
+	if len(a.Fields) != len(want) {
+		t.Fatalf("expected %d fields, got %+v", len(want), a.Fields)
+	}
+	for _, f := range a.Fields {
+		if want[f.Title] != f.Value {
+			t.Errorf("field %s = %q, want %q", f.Title, f.Value, want[f.Title])
+		}
+	}
+}
+
+func TestSlogHandler_Dedup(t *testing.T) {
+	url, received := collectMessages(t)
+	handler := NewSlogHandler(url, &SlogHandlerOptions{DedupWindow: time.Minute})
+	logger := slog.New(handler)
+
+	for range 5 {
+		logger.Error("db down")
+	}
+	logger.Error("cache down")
+	handler.Close()
+
+	if n := len(received()); n != 2 {
+		t.Errorf("expected 2 messages after dedup, got %d", n)
+	}
+}
+
+func TestSlogHandler_NonBlocking(t *testing.T) {
+	release := make(chan struct{})
+	server := mockWebhookServer(t, func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusOK)
+	})
+
+	var mu sync.Mutex
+	var dropped int
+	handler := NewSlogHandler(server.URL, &SlogHandlerOptions{
+		QueueSize: 1,
+		OnError: func(err error) {
+			mu.Lock()
+			dropped++
+			mu.Unlock()
+		},
+	})
+	logger := slog.New(handler)
+
+	start := time.Now()
+	for range 10 {
+		logger.Error("flood")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("logging blocked for %v", elapsed)
+	}
+
+	close(release)
+	handler.Close()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if dropped == 0 {
+		t.Error("expected records to be dropped when queue is full")
+	}
+}
+
+func TestSlogHandler_ReportsSendErrors(t *testing.T) {
+	server := mockWebhookServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	var got error
+	handler := NewSlogHandler(server.URL, &SlogHandlerOptions{
+		OnError: func(err error) { got = err },
+	})
+	slog.New(handler).Error("boom")
+	handler.Close()
+
+	var webhookErr *WebhookError
+	if !errors.As(got, &webhookErr) || webhookErr.StatusCode != http.StatusForbidden {
+		t.Errorf("expected 403 WebhookError, got %v", got)
+	}
+}
@@ -0,0 +1,234 @@
+package samhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// 預設封鎖的網段（除了 netip.Addr 內建判斷之外）
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),        // 本網路
+	netip.MustParsePrefix("100.64.0.0/10"),    // CGNAT
+	netip.MustParsePrefix("192.0.0.0/24"),     // IETF 協定保留（含 Oracle Cloud metadata）
+	netip.MustParsePrefix("198.18.0.0/15"),    // 效能測試
+	netip.MustParsePrefix("240.0.0.0/4"),      // 保留位址與廣播
+	netip.MustParsePrefix("64:ff9b::/96"),     // NAT64
+	netip.MustParsePrefix("64:ff9b:1::/48"),   // 本地 NAT64
+	netip.MustParsePrefix("2001::/32"),        // Teredo（內嵌 IPv4 位址）
+	netip.MustParsePrefix("2001:db8::/32"),    // 文件用途
+	netip.MustParsePrefix("2002::/16"),        // 6to4（內嵌 IPv4 位址）
+	netip.MustParsePrefix("fec0::/10"),        // 已棄用的 site-local
+	netip.MustParsePrefix("168.63.129.16/32"), // Azure wireserver
+}
+
+// SecureOptions 安全傳輸選項
+type SecureOptions struct {
+	// AllowedHosts 允許的主機名稱，支援 "*.example.com"；為空時不限制主機名稱
+	AllowedHosts []string
+
+	// AllowedNetworks 例外允許的網段（例如內部部署的 Mattermost）
+	AllowedNetworks []netip.Prefix
+
+	// Resolver DNS 解析器，為 nil 時使用 net.DefaultResolver
+	Resolver *net.Resolver
+
+	// DialTimeout 連線超時，為 0 時使用 DefaultTimeout
+	DialTimeout time.Duration
+}
+
+// BlockedAddressError 目標位址被安全傳輸封鎖
+type BlockedAddressError struct {
+	Host   string
+	IP     netip.Addr
+	Reason string
+}
+
+// Error 實現 error 介面
+func (e *BlockedAddressError) Error() string {
+	if e.IP.IsValid() {
+		return fmt.Sprintf("blocked address %s (%s): %s", e.Host, e.IP, e.Reason)
+	}
+	return fmt.Sprintf("blocked host %s: %s", e.Host, e.Reason)
+}
+
+// NewSecureTransport 創建防止 SSRF 的 http.Transport
+//
+// 每次建立連線時都會檢查實際連線的 IP，因此重新導向與 DNS rebinding
+// 同樣會被檢查。此傳輸不使用環境變數中的代理設定。
+func NewSecureTransport(opts *SecureOptions) *http.Transport {
+	guard := newAddressGuard(opts)
+	dialer := &net.Dialer{
+		Timeout:  guard.dialTimeout,
+		Resolver: guard.resolver,
+		Control:  guard.control,
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		if err := guard.checkHost(host); err != nil {
+			return nil, err
+		}
+		return dialer.DialContext(ctx, network, addr)
+	}
+	return transport
+}
+
+// NewSecureClient 創建使用安全傳輸的 http.Client，重新導向同樣受主機白名單限制
+func NewSecureClient(opts *SecureOptions) *http.Client {
+	guard := newAddressGuard(opts)
+	return &http.Client{
+		Timeout:   DefaultTimeout,
+		Transport: NewSecureTransport(opts),
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+			return guard.checkHost(req.URL.Hostname())
+		},
+	}
+}
+
+// WithSecureTransport 使用防止 SSRF 的傳輸（保留已設定的超時）
+func WithSecureTransport(opts *SecureOptions) ClientOption {
+	return func(c *http.Client) {
+		secure := NewSecureClient(opts)
+		c.Transport = secure.Transport
+		c.CheckRedirect = secure.CheckRedirect
+	}
+}
+
+// CheckWebhookURL 驗證 URL 格式並解析主機名稱，任一解析結果被封鎖時返回錯誤
+//
+// 適合在儲存使用者提供的 URL 時預先檢查；實際發送時仍應使用安全傳輸，
+// 以防止 DNS 紀錄在檢查後被更改。
+func CheckWebhookURL(ctx context.Context, webhookURL string, opts *SecureOptions) error {
+	if err := ValidateWebhookURL(webhookURL); err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, nil)
+	if err != nil {
+		return err
+	}
+
+	guard := newAddressGuard(opts)
+	host := req.URL.Hostname()
+	if err := guard.checkHost(host); err != nil {
+		return NewBlockedAddressError(webhookURL, err)
+	}
+
+	addrs, err := guard.resolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return NewNetworkError(webhookURL, err)
+	}
+	for _, ip := range addrs {
+		if err := guard.checkIP(host, ip); err != nil {
+			return NewBlockedAddressError(webhookURL, err)
+		}
+	}
+	return nil
+}
+
+// addressGuard 主機與 IP 檢查
+type addressGuard struct {
+	allowedHosts    []string
+	allowedNetworks []netip.Prefix
+	resolver        *net.Resolver
+	dialTimeout     time.Duration
+}
+
+func newAddressGuard(opts *SecureOptions) *addressGuard {
+	g := &addressGuard{
+		resolver:    net.DefaultResolver,
+		dialTimeout: DefaultTimeout,
+	}
+	if opts == nil {
+		return g
+	}
+	for _, h := range opts.AllowedHosts {
+		g.allowedHosts = append(g.allowedHosts, strings.ToLower(h))
+	}
+	g.allowedNetworks = opts.AllowedNetworks
+	if opts.Resolver != nil {
+		g.resolver = opts.Resolver
+	}
+	if opts.DialTimeout > 0 {
+		g.dialTimeout = opts.DialTimeout
+	}
+	return g
+}
+
+// control 在連線建立前檢查實際的目標 IP
+func (g *addressGuard) control(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return &BlockedAddressError{Host: address, Reason: "unparseable address"}
+	}
+	return g.checkIP(address, addrPort.Addr())
+}
+
+// checkHost 檢查主機名稱是否在白名單中
+func (g *addressGuard) checkHost(host string) error {
+	if len(g.allowedHosts) == 0 {
+		return nil
+	}
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, allowed := range g.allowedHosts {
+		if allowed == host {
+			return nil
+		}
+		if suffix, ok := strings.CutPrefix(allowed, "*"); ok && strings.HasSuffix(host, suffix) {
+			return nil
+		}
+	}
+	return &BlockedAddressError{Host: host, Reason: "host not in allowlist"}
+}
+
+// checkIP 檢查 IP 是否屬於封鎖的網段
+func (g *addressGuard) checkIP(host string, ip netip.Addr) error {
+	ip = ip.Unmap()
+	for _, prefix := range g.allowedNetworks {
+		if prefix.Contains(ip) {
+			return nil
+		}
+	}
+	if reason := blockedReason(ip); reason != "" {
+		return &BlockedAddressError{Host: host, IP: ip, Reason: reason}
+	}
+	return nil
+}
+
+// blockedReason 返回 IP 被封鎖的原因，允許時返回空字串
+func blockedReason(ip netip.Addr) string {
+	switch {
+	case !ip.IsValid():
+		return "invalid address"
+	case ip.IsLoopback():
+		return "loopback address"
+	case ip.IsLinkLocalUnicast(), ip.IsLinkLocalMulticast():
+		return "link-local address"
+	case ip.IsPrivate():
+		return "private address"
+	case ip.IsUnspecified():
+		return "unspecified address"
+	case ip.IsMulticast(), ip.IsInterfaceLocalMulticast():
+		return "multicast address"
+	}
+	for _, prefix := range blockedPrefixes {
+		if prefix.Contains(ip) {
+			return "reserved address " + prefix.String()
+		}
+	}
+	return ""
+}
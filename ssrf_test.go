@@ -0,0 +1,129 @@
+package samhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/netip"
+	"testing"
+)
+
+func TestSecureTransport_BlocksLoopback(t *testing.T) {
+	requests := 0
+	server := mockWebhookServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	})
+
+	err := SendWithOptions(server.URL, createTestMessage(), WithSecureTransport(nil))
+	if err == nil {
+		t.Fatal("expected loopback address to be blocked")
+	}
+	var webhookErr *WebhookError
+	if !errors.As(err, &webhookErr) || webhookErr.ErrorCode != ErrorCodeAddressBlocked {
+		t.Fatalf("expected %s error, got %v", ErrorCodeAddressBlocked, err)
+	}
+	if isRetryable(webhookErr) {
+		t.Error("blocked address should not be retryable")
+	}
+	var blocked *BlockedAddressError
+	if !errors.As(err, &blocked) || blocked.IP != netip.MustParseAddr("127.0.0.1") {
+		t.Errorf("expected BlockedAddressError for 127.0.0.1, got %v", err)
+	}
+	if requests != 0 {
+		t.Errorf("expected no request to reach the server, got %d", requests)
+	}
+}
+
+func TestSecureTransport_AllowedNetworks(t *testing.T) {
+	server := mockWebhookServer(t, func(w http.ResponseWriter, r *http.Request) {})
+
+	opts := &SecureOptions{AllowedNetworks: []netip.Prefix{netip.MustParsePrefix("127.0.0.0/8")}}
+	if err := SendWithOptions(server.URL, createTestMessage(), WithSecureTransport(opts)); err != nil {
+		t.Fatalf("expected allowed network to pass, got %v", err)
+	}
+}
+
+func TestSecureTransport_AllowedHosts(t *testing.T) {
+	server := mockWebhookServer(t, func(w http.ResponseWriter, r *http.Request) {})
+
+	opts := &SecureOptions{
+		AllowedHosts:    []string{"hooks.slack.com"},
+		AllowedNetworks: []netip.Prefix{netip.MustParsePrefix("127.0.0.0/8")},
+	}
+	err := SendWithOptions(server.URL, createTestMessage(), WithSecureTransport(opts))
+	var blocked *BlockedAddressError
+	if !errors.As(err, &blocked) || blocked.Reason != "host not in allowlist" {
+		t.Fatalf("expected host allowlist error, got %v", err)
+	}
+}
+
+func TestSecureTransport_BlocksRedirect(t *testing.T) {
+	server := mockWebhookServer(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://169.254.169.254/latest/meta-data/", http.StatusTemporaryRedirect)
+	})
+
+	opts := &SecureOptions{AllowedNetworks: []netip.Prefix{netip.MustParsePrefix("127.0.0.0/8")}}
+	err := SendWithOptions(server.URL, createTestMessage(), WithSecureTransport(opts))
+	var blocked *BlockedAddressError
+	if !errors.As(err, &blocked) || blocked.Reason != "link-local address" {
+		t.Fatalf("expected redirect to metadata service to be blocked, got %v", err)
+	}
+}
+
+func TestBlockedReason(t *testing.T) {
+	tests := []struct {
+		name    string
+		ip      string
+		blocked bool
+	}{
+		{"公開 IPv4", "93.184.216.34", false},
+		{"公開 IPv6", "2606:4700::1111", false},
+		{"loopback", "127.0.0.1", true},
+		{"IPv6 loopback", "::1", true},
+		{"私有網段", "10.1.2.3", true},
+		{"雲端 metadata", "169.254.169.254", true},
+		{"CGNAT", "100.100.100.200", true},
+		{"未指定位址", "0.0.0.0", true},
+		{"IPv6 ULA", "fd00:ec2::254", true},
+		{"保留位址", "255.255.255.255", true},
+		{"Azure wireserver", "168.63.129.16", true},
+		{"6to4 內嵌 loopback", "2002:7f00:1::1", true},
+		{"6to4 內嵌 metadata", "2002:a9fe:a9fe::", true},
+		{"Teredo", "2001:0:4136:e378:8000:63bf:3fff:fdd2", true},
+		{"NAT64", "64:ff9b::a9fe:a9fe", true},
+	}
+
+	guard := newAddressGuard(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := guard.checkIP("example.com", netip.MustParseAddr(tt.ip))
+			if (err != nil) != tt.blocked {
+				t.Errorf("checkIP(%s) = %v, want blocked=%v", tt.ip, err, tt.blocked)
+			}
+		})
+	}
+
+	// IPv4-mapped IPv6 位址應與 IPv4 相同處理
+	if err := guard.checkIP("example.com", netip.MustParseAddr("::ffff:127.0.0.1")); err == nil {
+		t.Error("expected IPv4-mapped loopback to be blocked")
+	}
+}
+
+func TestCheckWebhookURL(t *testing.T) {
+	ctx := context.Background()
+
+	err := CheckWebhookURL(ctx, "http://127.0.0.1:8065/hooks/abc", nil)
+	var webhookErr *WebhookError
+	if !errors.As(err, &webhookErr) || webhookErr.ErrorCode != ErrorCodeAddressBlocked {
+		t.Errorf("expected loopback URL to be blocked, got %v", err)
+	}
+
+	err = CheckWebhookURL(ctx, "https://evil.example.com/hook", &SecureOptions{AllowedHosts: []string{"*.slack.com"}})
+	if !errors.As(err, &webhookErr) || webhookErr.ErrorCode != ErrorCodeAddressBlocked {
+		t.Errorf("expected host outside allowlist to be blocked, got %v", err)
+	}
+
+	if err := CheckWebhookURL(ctx, "not a url", nil); err == nil {
+		t.Error("expected invalid URL error")
+	}
+}
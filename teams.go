@@ -0,0 +1,175 @@
+package samhook
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/bytedance/sonic"
+	"github.com/circleyu/samhook/format"
+)
+
+// TeamsProvider Microsoft Teams incoming webhook 與 Power Automate workflow
+//
+// 訊息編碼為 Adaptive Card，attachment 對應帶有顏色樣式的 Container。
+type TeamsProvider struct{}
+
+// Name 返回平台名稱
+func (TeamsProvider) Name() string { return ProviderNameTeams }
+
+// Limits 返回 Teams 的訊息限制（整體 payload 上限約 28 KB）
+func (TeamsProvider) Limits() Limits {
+	return Limits{
+		MaxTextLength:     28000,
+		MaxAttachmentText: 28000,
+	}
+}
+
+// adaptiveCardContentType Adaptive Card 的 attachment 類型
+const adaptiveCardContentType = "application/vnd.microsoft.card.adaptive"
+
+type teamsPayload struct {
+	Type        string            `json:"type"`
+	Attachments []teamsAttachment `json:"attachments"`
+}
+
+type teamsAttachment struct {
+	ContentType string       `json:"contentType"`
+	ContentURL  *string      `json:"contentUrl"`
+	Content     adaptiveCard `json:"content"`
+}
+
+type adaptiveCard struct {
+	Schema  string            `json:"$schema"`
+	Type    string            `json:"type"`
+	Version string            `json:"version"`
+	Body    []map[string]any  `json:"body"`
+	MSTeams map[string]string `json:"msteams,omitempty"`
+}
+
+// Encode 將訊息編碼為包含 Adaptive Card 的 Teams 訊息
+func (TeamsProvider) Encode(webhookURL string, msg Message) ([]byte, error) {
+	data, err := sonic.Marshal(teamsMessage(msg))
+	if err != nil {
+		return nil, NewSerializationError(err)
+	}
+	return data, nil
+}
+
+// CheckResponse 舊版 connector 成功時返回 200 與 "1"，workflow 返回 202
+//
+// 舊版 connector 在轉送失敗時仍可能返回 200，此時依回應內容判斷。
+func (TeamsProvider) CheckResponse(webhookURL string, statusCode int, body []byte) error {
+	if statusCode == http.StatusOK || statusCode == http.StatusAccepted {
+		text := strings.TrimSpace(string(body))
+		if !strings.Contains(text, "delivery failed") {
+			return nil
+		}
+	}
+	return NewAPIError(webhookURL, statusCode, string(body))
+}
+
+// teamsMessage 將訊息轉換為 Adaptive Card
+func teamsMessage(msg Message) teamsPayload {
+	var body []map[string]any
+	if msg.Text != "" {
+		body = append(body, textBlock(format.ToMarkdown(msg.Text)))
+	}
+	for _, a := range msg.Attachments {
+		body = append(body, teamsContainer(a))
+	}
+
+	return teamsPayload{
+		Type: "message",
+		Attachments: []teamsAttachment{{
+			ContentType: adaptiveCardContentType,
+			Content: adaptiveCard{
+				Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+				Type:    "AdaptiveCard",
+				Version: "1.4",
+				Body:    body,
+				MSTeams: map[string]string{"width": "Full"},
+			},
+		}},
+	}
+}
+
+// teamsContainer 將 attachment 轉換為 Container
+func teamsContainer(a Attachment) map[string]any {
+	var items []map[string]any
+	if a.Pretext != "" {
+		items = append(items, textBlock(format.ToMarkdown(a.Pretext)))
+	}
+	if a.AuthorName != "" {
+		author := a.AuthorName
+		if a.AuthorLink != "" {
+			author = "[" + author + "](" + a.AuthorLink + ")"
+		}
+		block := textBlock(author)
+		block["size"] = "Small"
+		block["isSubtle"] = true
+		items = append(items, block)
+	}
+	if a.Title != "" {
+		title := a.Title
+		if a.TitleLink != "" {
+			title = "[" + title + "](" + a.TitleLink + ")"
+		}
+		block := textBlock(title)
+		block["size"] = "Medium"
+		block["weight"] = "Bolder"
+		items = append(items, block)
+	}
+	if a.Text != "" {
+		items = append(items, textBlock(format.ToMarkdown(a.Text)))
+	}
+	if len(a.Fields) > 0 {
+		facts := make([]map[string]string, len(a.Fields))
+		for i, f := range a.Fields {
+			facts[i] = map[string]string{"title": f.Title, "value": format.ToMarkdown(f.Value)}
+		}
+		items = append(items, map[string]any{"type": "FactSet", "facts": facts})
+	}
+	if a.ImageURL != "" {
+		items = append(items, map[string]any{"type": "Image", "url": a.ImageURL})
+	}
+	if a.Footer != "" {
+		block := textBlock(a.Footer)
+		block["size"] = "Small"
+		block["isSubtle"] = true
+		items = append(items, block)
+	}
+	if len(items) == 0 && a.Fallback != "" {
+		items = append(items, textBlock(a.Fallback))
+	}
+
+	container := map[string]any{"type": "Container", "items": items}
+	style := teamsContainerStyle(a.Color)
+	if severityStyled(a) {
+		style = a.Severity.TeamsStyle()
+	}
+	if style != "" {
+		container["style"] = style
+	}
+	return container
+}
+
+// textBlock 建立可換行的 TextBlock
+func textBlock(text string) map[string]any {
+	return map[string]any{"type": "TextBlock", "text": text, "wrap": true}
+}
+
+// teamsContainerStyle 將 attachment 顏色對應為 Container 樣式
+func teamsContainerStyle(color string) string {
+	switch strings.ToLower(color) {
+	case "":
+		return ""
+	case "good", strings.ToLower(Good):
+		return "good"
+	case "warning", strings.ToLower(Warning):
+		return "warning"
+	case "danger", strings.ToLower(Danger):
+		return "attention"
+	default:
+		return "emphasis"
+	}
+}
@@ -0,0 +1,63 @@
+package samhook
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bytedance/sonic"
+)
+
+func TestTeamsProvider_Encode(t *testing.T) {
+	msg := Message{
+		Text: "deploy finished",
+		Attachments: []Attachment{{
+			Color:  Warning,
+			Title:  "api",
+			Fields: []Field{{Title: "Region", Value: "us-east-1"}},
+		}},
+	}
+
+	data, err := TeamsProvider{}.Encode("", msg)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	var payload teamsPayload
+	if err := sonic.Unmarshal(data, &payload); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if payload.Type != "message" || len(payload.Attachments) != 1 {
+		t.Fatalf("unexpected payload: %s", data)
+	}
+	card := payload.Attachments[0].Content
+	if card.Type != "AdaptiveCard" || len(card.Body) != 2 {
+		t.Fatalf("unexpected card: %s", data)
+	}
+	if card.Body[0]["text"] != "deploy finished" {
+		t.Errorf("unexpected text block: %v", card.Body[0])
+	}
+	if card.Body[1]["type"] != "Container" || card.Body[1]["style"] != "warning" {
+		t.Errorf("unexpected container: %v", card.Body[1])
+	}
+}
+
+func TestTeamsProvider_CheckResponse(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr bool
+	}{
+		{"connector 成功", http.StatusOK, "1", false},
+		{"workflow 成功", http.StatusAccepted, "", false},
+		{"connector 轉送失敗", http.StatusOK, "Webhook message delivery failed with error: Microsoft Teams endpoint returned HTTP error 413", true},
+		{"請求錯誤", http.StatusBadRequest, "Bad payload received by generic incoming webhook.", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := TeamsProvider{}.CheckResponse("https://example.webhook.office.com", tt.status, []byte(tt.body))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckResponse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,293 @@
+package samhook
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bytedance/sonic"
+	"github.com/circleyu/samhook/format"
+)
+
+// Telegram 的 parse_mode
+const (
+	TelegramHTML       = "HTML"
+	TelegramMarkdownV2 = "MarkdownV2"
+)
+
+// DefaultTelegramBaseURL Telegram Bot API 的預設位址
+const DefaultTelegramBaseURL = "https://api.telegram.org"
+
+// telegramMaxLength Telegram 訊息的長度上限（解析格式後的字元數）
+const telegramMaxLength = 4096
+
+// TelegramURL 返回 sendMessage 端點的 URL，baseURL 為空時使用 DefaultTelegramBaseURL
+//
+// 可將 baseURL 指向本地的 Bot API 伺服器或測試用的模擬伺服器。
+func TelegramURL(baseURL, token string) string {
+	if baseURL == "" {
+		baseURL = DefaultTelegramBaseURL
+	}
+	return strings.TrimSuffix(baseURL, "/") + "/bot" + token + "/sendMessage"
+}
+
+// TelegramProvider Telegram Bot API sendMessage
+//
+// 訊息轉換為 HTML 或 MarkdownV2 並跳脫特殊字元，attachment 轉換為帶有標題的段落；
+// 超過 4096 字元的訊息會拆分為多則。ChatID 為空時使用 URL 中的 chat_id 參數。
+type TelegramProvider struct {
+	// ChatID 目標聊天（數字 ID 或 @channelusername）
+	ChatID string
+
+	// MessageThreadID 論壇主題 ID，為 0 時發送到一般主題
+	MessageThreadID int
+
+	// DisableNotification 靜音發送
+	DisableNotification bool
+
+	// ParseMode 格式（TelegramHTML 或 TelegramMarkdownV2），為空時使用 TelegramHTML
+	ParseMode string
+}
+
+// Name 返回平台名稱
+func (TelegramProvider) Name() string { return ProviderNameTelegram }
+
+// Limits 返回 Telegram 的訊息限制（過長的訊息由 Split 拆分，因此不限制文字長度）
+func (TelegramProvider) Limits() Limits {
+	return Limits{}
+}
+
+type telegramPayload struct {
+	ChatID              string `json:"chat_id"`
+	MessageThreadID     int    `json:"message_thread_id,omitempty"`
+	Text                string `json:"text"`
+	ParseMode           string `json:"parse_mode"`
+	DisableNotification bool   `json:"disable_notification,omitempty"`
+}
+
+// telegramResponse Bot API 的回應格式
+type telegramResponse struct {
+	OK          bool   `json:"ok"`
+	ErrorCode   int    `json:"error_code"`
+	Description string `json:"description"`
+	Parameters  struct {
+		RetryAfter int `json:"retry_after"`
+	} `json:"parameters"`
+}
+
+// Encode 將訊息編碼為 sendMessage 請求
+func (p TelegramProvider) Encode(webhookURL string, msg Message) ([]byte, error) {
+	payload := telegramPayload{
+		ChatID:              p.ChatID,
+		MessageThreadID:     p.MessageThreadID,
+		ParseMode:           p.parseMode(),
+		DisableNotification: p.DisableNotification,
+		Text:                telegramText(p.formatter(), msg),
+	}
+	if u, err := url.Parse(webhookURL); err == nil {
+		query := u.Query()
+		if payload.ChatID == "" {
+			payload.ChatID = query.Get("chat_id")
+		}
+		if payload.MessageThreadID == 0 {
+			payload.MessageThreadID, _ = strconv.Atoi(query.Get("message_thread_id"))
+		}
+	}
+	if payload.ChatID == "" {
+		return nil, NewValidationError(ErrorCodeInvalidMessage, errors.New("telegram chat_id is required"))
+	}
+
+	data, err := sonic.Marshal(payload)
+	if err != nil {
+		return nil, NewSerializationError(err)
+	}
+	return data, nil
+}
+
+// CheckResponse 解析 {"ok":false,...} 回應，429 時讀取 parameters.retry_after
+func (TelegramProvider) CheckResponse(webhookURL string, statusCode int, body []byte) error {
+	var resp telegramResponse
+	if err := sonic.Unmarshal(body, &resp); err != nil {
+		if statusCode == http.StatusOK {
+			return nil
+		}
+		return NewAPIError(webhookURL, statusCode, string(body))
+	}
+	if resp.OK {
+		return nil
+	}
+	apiErr := NewProviderError(webhookURL, statusCode, string(body), strconv.Itoa(resp.ErrorCode), resp.Description)
+	apiErr.RetryAfter = time.Duration(resp.Parameters.RetryAfter) * time.Second
+	return apiErr
+}
+
+// Split 將超過 4096 字元的訊息拆分為多則
+//
+// 文字在換行或空白處拆分，attachment 依渲染後的長度依序分配到各則訊息；
+// 單一 attachment 過長時拆分其內容與欄位，不截斷。
+func (TelegramProvider) Split(msg Message) []Message {
+	base := msg
+	base.Text, base.Attachments, base.Blocks = "", nil, nil
+
+	var parts []Message
+	chunks := splitText(msg.Text, telegramMaxLength)
+	for _, chunk := range chunks[:max(len(chunks)-1, 0)] {
+		part := base
+		part.Text = chunk
+		parts = append(parts, part)
+	}
+
+	current := base
+	if len(chunks) > 0 {
+		current.Text = chunks[len(chunks)-1]
+	}
+	size := utf8.RuneCountInString(current.Text)
+	for _, a := range msg.Attachments {
+		for _, piece := range splitTelegramAttachment(a) {
+			n := attachmentLength(piece)
+			if size > 0 && size+n+2 > telegramMaxLength {
+				parts = append(parts, current)
+				current, size = base, 0
+			}
+			current.Attachments = append(current.Attachments, piece)
+			size += n + 2
+		}
+	}
+	parts = append(parts, current)
+	parts[0].Blocks = msg.Blocks
+	return parts
+}
+
+func (p TelegramProvider) parseMode() string {
+	if p.ParseMode == "" {
+		return TelegramHTML
+	}
+	return p.ParseMode
+}
+
+func (p TelegramProvider) formatter() format.Formatter {
+	if p.parseMode() == TelegramMarkdownV2 {
+		return format.TelegramMarkdownV2
+	}
+	return format.TelegramHTML
+}
+
+// telegramText 將訊息轉換為 Telegram 格式，attachment 以顏色符號與粗體標題分段
+func telegramText(f format.Formatter, msg Message) string {
+	var sections []string
+	if msg.Text != "" {
+		sections = append(sections, format.Convert(f, msg.Text))
+	}
+	for _, a := range msg.Attachments {
+		var lines []string
+		if a.Pretext != "" {
+			lines = append(lines, format.Convert(f, a.Pretext))
+		}
+		if a.Title != "" {
+			title := f.Bold(f.Escape(a.Title))
+			if a.TitleLink != "" {
+				title = f.Bold(f.Link(a.TitleLink, a.Title))
+			}
+			if marker := attachmentMarker(a); marker != "" {
+				title = marker + " " + title
+			}
+			lines = append(lines, title)
+		}
+		if a.AuthorName != "" {
+			lines = append(lines, f.Italic(f.Escape(a.AuthorName)))
+		}
+		if a.Text != "" {
+			lines = append(lines, format.Convert(f, a.Text))
+		}
+		for _, field := range a.Fields {
+			lines = append(lines, f.Bold(f.Escape(field.Title+":"))+" "+format.Convert(f, field.Value))
+		}
+		if a.ImageURL != "" {
+			lines = append(lines, f.Link(a.ImageURL, firstNonEmpty(a.Fallback, a.ImageURL)))
+		}
+		if a.Footer != "" {
+			lines = append(lines, f.Italic(f.Escape(a.Footer)))
+		}
+		if len(lines) == 0 {
+			lines = append(lines, f.Escape(a.Fallback))
+		}
+		sections = append(sections, strings.Join(lines, "\n"))
+	}
+	return strings.Join(sections, "\n\n")
+}
+
+// attachmentMarker 返回標題前的標記，依嚴重程度決定樣式時使用其 emoji（標題已以其 emoji 開頭時不重複）
+func attachmentMarker(a Attachment) string {
+	if !severityStyled(a) {
+		return colorMarker(a.Color)
+	}
+	if strings.HasPrefix(a.Title, a.Severity.Emoji()) {
+		return ""
+	}
+	return a.Severity.Emoji()
+}
+
+// colorMarker 將 attachment 顏色對應為顏色符號（用於不支援顏色的平台）
+func colorMarker(color string) string {
+	switch strings.ToLower(color) {
+	case "good", strings.ToLower(Good):
+		return "🟢"
+	case "warning", strings.ToLower(Warning):
+		return "🟡"
+	case "danger", strings.ToLower(Danger):
+		return "🔴"
+	}
+	return ""
+}
+
+// attachmentLength 估計 attachment 顯示的字元數
+func attachmentLength(a Attachment) int {
+	n := 0
+	for _, s := range []string{a.Pretext, a.Title, a.AuthorName, a.Text, a.Footer, a.ImageURL} {
+		if s != "" {
+			n += utf8.RuneCountInString(s) + 1
+		}
+	}
+	for _, f := range a.Fields {
+		n += utf8.RuneCountInString(f.Title) + utf8.RuneCountInString(f.Value) + 3
+	}
+	return n
+}
+
+// splitTelegramAttachment 將渲染後超過 4096 字元的 attachment 拆分為多個
+//
+// 先以 splitAttachment 拆分過長的標題、內容與欄位值，再依渲染後的長度將
+// 欄位分配到接續的 attachment。
+func splitTelegramAttachment(a Attachment) []Attachment {
+	if attachmentLength(a) <= telegramMaxLength {
+		return []Attachment{a}
+	}
+	pieces := splitAttachment(a, Limits{
+		MaxTitleLength:      telegramMaxLength / 8,
+		MaxAttachmentText:   telegramMaxLength / 4,
+		MaxFieldTitleLength: telegramMaxLength / 16,
+		MaxFieldValueLength: telegramMaxLength / 4,
+	})
+
+	var out []Attachment
+	for _, piece := range pieces {
+		fields := piece.Fields
+		piece.Fields = nil
+		for _, f := range fields {
+			next := piece
+			next.Fields = append(piece.Fields, f)
+			if attachmentLength(next) > telegramMaxLength && attachmentLength(piece) > 0 {
+				out = append(out, piece)
+				next = continuationAttachment(a)
+				next.Fields = []Field{f}
+			}
+			piece = next
+		}
+		out = append(out, piece)
+	}
+	return out
+}
@@ -0,0 +1,202 @@
+package samhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bytedance/sonic"
+)
+
+func TestTelegramProvider_Encode(t *testing.T) {
+	msg := Message{
+		Text: "*部署* <https://example.com|v1.2> 完成 a<b",
+		Attachments: []Attachment{{
+			Color:  Danger,
+			Title:  "api & web",
+			Fields: []Field{{Title: "Region", Value: "us-east-1"}},
+			Footer: "ci",
+		}},
+	}
+
+	tests := []struct {
+		name     string
+		provider TelegramProvider
+		url      string
+		want     []string
+	}{
+		{
+			name:     "HTML",
+			provider: TelegramProvider{ChatID: "42", DisableNotification: true},
+			want: []string{
+				`<b>部署</b> <a href="https://example.com">v1.2</a> 完成 a&lt;b`,
+				"🔴 <b>api &amp; web</b>",
+				"<b>Region:</b> us-east-1",
+				"<i>ci</i>",
+			},
+		},
+		{
+			name:     "MarkdownV2",
+			provider: TelegramProvider{ChatID: "42", ParseMode: TelegramMarkdownV2},
+			want: []string{
+				`*部署* [v1\.2](https://example.com) 完成 a<b`,
+				"🔴 *api & web*",
+				`*Region:* us\-east\-1`,
+			},
+		},
+		{
+			name:     "chat_id 從 URL 取得",
+			provider: TelegramProvider{},
+			url:      "https://api.telegram.org/bot1:x/sendMessage?chat_id=-100&message_thread_id=7",
+			want:     []string{"<b>部署</b>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.provider.Encode(tt.url, msg)
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			var payload telegramPayload
+			if err := sonic.Unmarshal(data, &payload); err != nil {
+				t.Fatalf("invalid JSON: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(payload.Text, want) {
+					t.Errorf("text missing %q:\n%s", want, payload.Text)
+				}
+			}
+			if payload.ParseMode != tt.provider.parseMode() {
+				t.Errorf("parse_mode = %q", payload.ParseMode)
+			}
+			if tt.url != "" && (payload.ChatID != "-100" || payload.MessageThreadID != 7) {
+				t.Errorf("expected chat_id and thread from URL, got %s", data)
+			}
+		})
+	}
+
+	_, err := TelegramProvider{}.Encode(TelegramURL("", "1:x"), msg)
+	var webhookErr *WebhookError
+	if !errors.As(err, &webhookErr) || webhookErr.Type != ErrorTypeValidation {
+		t.Errorf("expected validation error without chat_id, got %v", err)
+	}
+}
+
+func TestTelegramProvider_Split(t *testing.T) {
+	attachments := make([]Attachment, 3)
+	for i := range attachments {
+		attachments[i] = Attachment{Title: "section", Text: strings.Repeat("x", 2000)}
+	}
+	msg := Message{Text: strings.Repeat("line\n", 1000), Attachments: attachments}
+
+	parts := TelegramProvider{}.Split(msg)
+	if len(parts) < 3 {
+		t.Fatalf("expected message to be split, got %d parts", len(parts))
+	}
+	count := 0
+	for _, part := range parts {
+		if n := utf8.RuneCountInString(part.Text); n > telegramMaxLength {
+			t.Errorf("part text has %d characters", n)
+		}
+		count += len(part.Attachments)
+	}
+	if count != len(attachments) {
+		t.Errorf("expected %d attachments across parts, got %d", len(attachments), count)
+	}
+
+	blocks := TelegramProvider{}.Split(Message{Text: msg.Text, Blocks: []Block{{"type": "divider"}}})
+	for i, part := range blocks {
+		if len(part.Blocks) != 0 && i != 0 {
+			t.Errorf("part %d repeats blocks", i)
+		}
+	}
+
+	// 單一 attachment 的欄位渲染後超過上限時拆分欄位，不截斷
+	fields := make([]Field, 40)
+	for i := range fields {
+		fields[i] = Field{Title: "host", Value: strings.Repeat("y", 200)}
+	}
+	fields[0].Value = strings.Repeat("z", 5000)
+	wide := TelegramProvider{}.Split(Message{Attachments: []Attachment{{Fallback: "hosts", Title: "hosts", Text: "summary", Fields: fields}}})
+	total := 0
+	for i, part := range wide {
+		size := utf8.RuneCountInString(part.Text)
+		for _, a := range part.Attachments {
+			size += attachmentLength(a) + 2
+			for _, f := range a.Fields {
+				total += utf8.RuneCountInString(f.Value)
+			}
+		}
+		if size > telegramMaxLength {
+			t.Errorf("part %d renders %d characters", i, size)
+		}
+	}
+	if want := 5000 + 39*200; total != want {
+		t.Errorf("expected %d field characters across parts, got %d", want, total)
+	}
+
+	short := createTestMessage()
+	if parts := (TelegramProvider{}).Split(short); len(parts) != 1 {
+		t.Errorf("expected short message to stay whole, got %d parts", len(parts))
+	}
+}
+
+func TestTelegramProvider_RetryAfter(t *testing.T) {
+	attempts := 0
+	server := mockWebhookServer(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"ok":false,"error_code":429,"description":"Too Many Requests: retry after 1","parameters":{"retry_after":1}}`))
+			return
+		}
+		w.Write([]byte(`{"ok":true,"result":{}}`))
+	})
+
+	dest := Destination{
+		URL:      TelegramURL(server.URL, "1:x"),
+		Provider: TelegramProvider{ChatID: "42"},
+		Retry:    &RetryOptions{MaxRetries: 1, Interval: time.Millisecond},
+	}
+	start := time.Now()
+	if err := dest.Send(context.Background(), createTestMessage()); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("expected 2 attempts, got %d", attempts)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("expected retry_after to be honored, retried after %v", elapsed)
+	}
+
+	err := TelegramProvider{}.CheckResponse("u", http.StatusBadRequest, []byte(`{"ok":false,"error_code":400,"description":"Bad Request: chat not found"}`))
+	var webhookErr *WebhookError
+	if !errors.As(err, &webhookErr) || webhookErr.ProviderCode != "400" || isRetryable(webhookErr) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{name: "秒數", value: "3", want: 3 * time.Second},
+		{name: "空值", value: "", want: 0},
+		{name: "無效", value: "soon", want: 0},
+		{name: "過去的日期", value: "Mon, 01 Jan 2001 00:00:00 GMT", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseRetryAfter(tt.value); got != tt.want {
+				t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
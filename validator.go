@@ -3,9 +3,54 @@ package samhook
 import (
 	"fmt"
 	"net/url"
+	"regexp"
 	"strings"
+	"sync/atomic"
 )
 
+var (
+	hexColorPattern  = regexp.MustCompile(`^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+	emojiPattern     = regexp.MustCompile(`^:[a-z0-9_+\-']+:$`)
+	channelPattern   = regexp.MustCompile(`^[#@]?[\p{Ll}\p{Lo}\p{N}._\-]{1,80}$`)
+	channelIDPattern = regexp.MustCompile(`^[CGDU][A-Z0-9]{6,}$`)
+)
+
+// maxBlocks Slack 每則訊息的區塊上限
+const maxBlocks = 50
+
+// 具名顏色（Slack 與 Mattermost 皆支援）
+var namedColors = map[string]bool{
+	"good":    true,
+	"warning": true,
+	"danger":  true,
+}
+
+// ValidationProblem 訊息驗證發現的單一問題
+type ValidationProblem struct {
+	Field  string
+	Reason string
+}
+
+// String 返回可讀的描述
+func (p ValidationProblem) String() string {
+	return fmt.Sprintf("%s: %s", p.Field, p.Reason)
+}
+
+// InvalidMessageError 訊息驗證失敗的詳細資訊
+type InvalidMessageError struct {
+	Provider string
+	Problems []ValidationProblem
+}
+
+// Error 實現 error 介面
+func (e *InvalidMessageError) Error() string {
+	parts := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		parts[i] = p.String()
+	}
+	return fmt.Sprintf("invalid %s message: %s", e.Provider, strings.Join(parts, "; "))
+}
+
 // ValidateWebhookURL 驗證 webhook URL 格式和協議
 func ValidateWebhookURL(webhookURL string) error {
 	if webhookURL == "" {
@@ -30,3 +75,142 @@ func ValidateWebhookURL(webhookURL string) error {
 
 	return nil
 }
+
+// Validate 依照平台規則驗證訊息，返回所有發現的問題（無問題時返回 nil）
+func (m Message) Validate(provider Provider) []ValidationProblem {
+	if provider == nil {
+		provider = SlackProvider{}
+	}
+
+	var problems []ValidationProblem
+	add := func(field, reason string) {
+		problems = append(problems, ValidationProblem{Field: field, Reason: reason})
+	}
+
+	// 只有 Slack 會顯示區塊，其他平台只有區塊的訊息會編碼為空的內容
+	hasBlocks := len(m.Blocks) > 0 && provider.Name() == ProviderNameSlack
+	if m.Text == "" && len(m.Attachments) == 0 && !hasBlocks {
+		add("message", "message has no text or attachments")
+	}
+	if len(m.Blocks) > maxBlocks {
+		add("blocks", fmt.Sprintf("message has %d blocks, at most %d are allowed", len(m.Blocks), maxBlocks))
+	}
+	if m.IconURL != "" && m.IconEmoji != "" {
+		add("icon_url", "icon_url and icon_emoji cannot both be set")
+	}
+	if m.IconURL != "" {
+		if err := validateHTTPURL(m.IconURL); err != nil {
+			add("icon_url", err.Error())
+		}
+	}
+	if m.IconEmoji != "" && !emojiPattern.MatchString(m.IconEmoji) {
+		add("icon_emoji", fmt.Sprintf("invalid emoji %q, expected :name:", m.IconEmoji))
+	}
+	if m.Channel != "" && !channelPattern.MatchString(m.Channel) && !channelIDPattern.MatchString(m.Channel) {
+		add("channel", fmt.Sprintf("invalid channel name %q", m.Channel))
+	}
+
+	for i, a := range m.Attachments {
+		prefix := fmt.Sprintf("attachments[%d]", i)
+		if a.Fallback == "" {
+			add(prefix+".fallback", "attachment is missing fallback text")
+		}
+		if a.Color != "" && !isValidColor(a.Color) {
+			add(prefix+".color", fmt.Sprintf("invalid color %q, expected hex or good/warning/danger", a.Color))
+		}
+		if a.Severity != "" && !a.Severity.Valid() {
+			add(prefix+".severity", fmt.Sprintf("unknown severity %q", a.Severity))
+		}
+		urls := []struct {
+			field string
+			value string
+		}{
+			{"author_link", a.AuthorLink},
+			{"author_icon", a.AuthorIcon},
+			{"title_link", a.TitleLink},
+			{"image_url", a.ImageURL},
+			{"thumb_url", a.ThumbURL},
+			{"footer_icon", a.FooterIcon},
+		}
+		for _, u := range urls {
+			if u.value == "" {
+				continue
+			}
+			if err := validateHTTPURL(u.value); err != nil {
+				add(prefix+"."+u.field, err.Error())
+			}
+		}
+		for j, action := range a.Actions {
+			field := fmt.Sprintf("%s.actions[%d]", prefix, j)
+			if action.Type != ActionButton && action.Type != ActionSelect {
+				add(field+".type", fmt.Sprintf("invalid action type %q, expected button or select", action.Type))
+			}
+			if action.URL != "" {
+				if err := validateHTTPURL(action.URL); err != nil {
+					add(field+".url", err.Error())
+				}
+			}
+			if action.Integration != nil {
+				if err := validateHTTPURL(action.Integration.URL); err != nil {
+					add(field+".integration.url", err.Error())
+				}
+			}
+			if action.Confirm != nil && action.Confirm.Text == "" {
+				add(field+".confirm.text", "confirm dialog is missing text")
+			}
+		}
+		if len(a.Actions) > 0 && a.CallbackID == "" && provider.Name() == ProviderNameSlack {
+			add(prefix+".callback_id", "attachment with actions is missing callback_id")
+		}
+	}
+
+	for _, v := range provider.Limits().Check(m) {
+		add(v.Field, fmt.Sprintf("length %d exceeds limit %d", v.Length, v.Limit))
+	}
+
+	return problems
+}
+
+// validateMessage 驗證訊息，失敗時返回 WebhookError
+func validateMessage(msg Message, provider Provider) error {
+	problems := msg.Validate(provider)
+	if len(problems) == 0 {
+		return nil
+	}
+	if provider == nil {
+		provider = SlackProvider{}
+	}
+	return NewValidationError(ErrorCodeInvalidMessage, &InvalidMessageError{
+		Provider: provider.Name(),
+		Problems: problems,
+	})
+}
+
+// 包級別的自動驗證開關（可在多個 goroutine 中同時讀寫）
+var autoValidate atomic.Bool
+
+// SetAutoValidate 設置發送前是否依目標平台自動驗證訊息
+func SetAutoValidate(enabled bool) {
+	autoValidate.Store(enabled)
+}
+
+// isValidColor 判斷是否為合法的 attachment 顏色
+func isValidColor(color string) bool {
+	return namedColors[color] || hexColorPattern.MatchString(color)
+}
+
+// validateHTTPURL 驗證一般的 http(s) URL（圖示、圖片、連結等）
+func validateHTTPURL(rawURL string) error {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid URL format: %w", err)
+	}
+	scheme := strings.ToLower(parsedURL.Scheme)
+	if scheme != "http" && scheme != "https" {
+		return fmt.Errorf("URL scheme must be http or https, got: %q", parsedURL.Scheme)
+	}
+	if parsedURL.Host == "" {
+		return fmt.Errorf("URL must have a host")
+	}
+	return nil
+}
@@ -1,6 +1,12 @@
 package samhook
 
-import "testing"
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
 
 func TestValidateWebhookURL(t *testing.T) {
 	tests := []struct {
@@ -54,3 +60,177 @@ func TestValidateWebhookURL(t *testing.T) {
 		})
 	}
 }
+
+func TestMessage_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		msg        Message
+		wantFields []string
+	}{
+		{
+			name: "有效訊息",
+			msg: Message{
+				Text:      "hello",
+				Channel:   "#general",
+				IconEmoji: ":robot_face:",
+				Attachments: []Attachment{
+					{Fallback: "fb", Color: Good, TitleLink: "https://example.com"},
+					{Fallback: "fb", Color: "danger"},
+				},
+			},
+		},
+		{
+			name:       "空訊息",
+			msg:        Message{},
+			wantFields: []string{"message"},
+		},
+		{
+			name: "同時設置 icon_url 與 icon_emoji",
+			msg: Message{
+				Text:      "hello",
+				IconURL:   "https://example.com/icon.png",
+				IconEmoji: ":robot_face:",
+			},
+			wantFields: []string{"icon_url"},
+		},
+		{
+			name:       "無效的 icon_url",
+			msg:        Message{Text: "hello", IconURL: "ftp://example.com/icon.png"},
+			wantFields: []string{"icon_url"},
+		},
+		{
+			name:       "無效的 icon_emoji",
+			msg:        Message{Text: "hello", IconEmoji: "robot_face"},
+			wantFields: []string{"icon_emoji"},
+		},
+		{
+			name:       "無效的頻道名稱",
+			msg:        Message{Text: "hello", Channel: "#General Chat"},
+			wantFields: []string{"channel"},
+		},
+		{
+			name:       "頻道 ID",
+			msg:        Message{Text: "hello", Channel: "C024BE91L"},
+			wantFields: nil,
+		},
+		{
+			name: "attachment 問題",
+			msg: Message{
+				Attachments: []Attachment{
+					{Color: "red", ImageURL: "not a url"},
+				},
+			},
+			wantFields: []string{
+				"attachments[0].fallback",
+				"attachments[0].color",
+				"attachments[0].image_url",
+			},
+		},
+		{
+			name: "互動按鈕",
+			msg: Message{
+				Attachments: []Attachment{{
+					Fallback:   "approve?",
+					CallbackID: "deploy_approval",
+					Actions: []Action{
+						{Name: "approve", Text: "Approve", Type: ActionButton, Style: ActionStylePrimary, Confirm: &Confirm{Text: "Sure?"}},
+						{Name: "env", Type: ActionSelect, Options: []Option{{Text: "prod", Value: "prod"}}},
+					},
+				}},
+			},
+		},
+		{
+			name: "互動按鈕問題",
+			msg: Message{
+				Attachments: []Attachment{{
+					Fallback: "approve?",
+					Actions: []Action{
+						{Name: "approve", Type: "link", Confirm: &Confirm{}},
+						{Name: "docs", Type: ActionButton, URL: "javascript:alert(1)"},
+					},
+				}},
+			},
+			wantFields: []string{
+				"attachments[0].actions[0].type",
+				"attachments[0].actions[0].confirm.text",
+				"attachments[0].actions[1].url",
+				"attachments[0].callback_id",
+			},
+		},
+		{
+			name:       "未知的嚴重程度",
+			msg:        Message{Attachments: []Attachment{{Fallback: "fb", Severity: "fatal"}}},
+			wantFields: []string{"attachments[0].severity"},
+		},
+		{
+			name:       "超出平台限制",
+			msg:        Message{Text: strings.Repeat("a", 40001)},
+			wantFields: []string{"text"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems := tt.msg.Validate(SlackProvider{})
+			var got []string
+			for _, p := range problems {
+				got = append(got, p.Field)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.wantFields, ",") {
+				t.Errorf("Validate() fields = %v, want %v (problems: %v)", got, tt.wantFields, problems)
+			}
+		})
+	}
+}
+
+func TestSetAutoValidate(t *testing.T) {
+	requests := 0
+	server := mockWebhookServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusOK)
+	})
+
+	SetAutoValidate(true)
+	t.Cleanup(func() { SetAutoValidate(false) })
+
+	err := Send(server.URL, Message{})
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+	var webhookErr *WebhookError
+	if !errors.As(err, &webhookErr) || !webhookErr.IsValidationError() {
+		t.Fatalf("expected validation WebhookError, got %v", err)
+	}
+	if webhookErr.GetErrorCode() != ErrorCodeInvalidMessage {
+		t.Errorf("expected %s, got %s", ErrorCodeInvalidMessage, webhookErr.GetErrorCode())
+	}
+	var invalidErr *InvalidMessageError
+	if !errors.As(err, &invalidErr) || len(invalidErr.Problems) != 1 {
+		t.Errorf("expected InvalidMessageError with 1 problem, got %v", err)
+	}
+	if requests != 0 {
+		t.Error("invalid message should not be sent")
+	}
+
+	if err := SendWithContext(context.Background(), server.URL, createTestMessage()); err != nil {
+		t.Fatalf("SendWithContext() error = %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("expected 1 request, got %d", requests)
+	}
+
+	// 依目標平台驗證：只有區塊的訊息在 Slack 合法，在 Discord 則不合法
+	blocksOnly := Message{Blocks: []Block{{"type": "divider"}}}
+	slack := &Destination{URL: server.URL, Provider: SlackProvider{}}
+	if err := slack.Send(context.Background(), blocksOnly); err != nil {
+		t.Fatalf("Send() to Slack error = %v", err)
+	}
+	discord := &Destination{URL: server.URL, Provider: DiscordProvider{}}
+	err = discord.Send(context.Background(), blocksOnly)
+	if !errors.As(err, &webhookErr) || !webhookErr.IsValidationError() {
+		t.Errorf("expected validation error for Discord, got %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("expected 2 requests, got %d", requests)
+	}
+}
@@ -0,0 +1,182 @@
+package samhook
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bytedance/sonic"
+	"github.com/circleyu/samhook/format"
+)
+
+// 企業微信的訊息類型
+const (
+	WeComMarkdown = "markdown"
+	WeComText     = "text"
+)
+
+// 企業微信的內容長度上限（位元組）
+const (
+	weComMaxMarkdownBytes = 4096
+	weComMaxTextBytes     = 2048
+)
+
+// 企業微信的限流錯誤代碼（超過每分鐘 20 則）
+const weComRateLimitCode = 45009
+
+// WeComProvider 企業微信群機器人
+//
+// 預設編碼為 markdown，attachment 顏色對應為 <font color> 標籤；內容超過
+// 位元組上限時會截斷。含有 slice 欄位，以指標（&WeComProvider{}）使用，
+// 讓平台值可以互相比較。
+type WeComProvider struct {
+	// MsgType 訊息類型（WeComMarkdown 或 WeComText），為空時使用 WeComMarkdown
+	MsgType string
+
+	// MentionedList 要 @ 的成員 userid，"@all" 表示所有人
+	MentionedList []string
+
+	// MentionedMobiles 要 @ 的成員手機號碼（僅 WeComText 支援）
+	MentionedMobiles []string
+}
+
+// Name 返回平台名稱
+func (*WeComProvider) Name() string { return ProviderNameWeCom }
+
+// Limits 返回企業微信的訊息限制（以位元組計算）
+//
+// 限制適用於組合後的完整內容，編碼時仍會截斷超出的部分。
+func (p *WeComProvider) Limits() Limits {
+	limit := weComMaxMarkdownBytes
+	if p.MsgType == WeComText {
+		limit = weComMaxTextBytes
+	}
+	return Limits{
+		MaxTextLength:     limit,
+		MaxAttachmentText: limit,
+		Bytes:             true,
+	}
+}
+
+type weComPayload struct {
+	MsgType  string        `json:"msgtype"`
+	Markdown *weComContent `json:"markdown,omitempty"`
+	Text     *weComContent `json:"text,omitempty"`
+}
+
+type weComContent struct {
+	Content             string   `json:"content"`
+	MentionedList       []string `json:"mentioned_list,omitempty"`
+	MentionedMobileList []string `json:"mentioned_mobile_list,omitempty"`
+}
+
+// Encode 將訊息編碼為企業微信格式
+func (p *WeComProvider) Encode(webhookURL string, msg Message) ([]byte, error) {
+	var payload weComPayload
+	if p.MsgType == WeComText {
+		payload = weComPayload{MsgType: WeComText, Text: &weComContent{
+			Content:             truncateBytes(markdownMessage(msg, false), weComMaxTextBytes, DefaultTruncateMarker),
+			MentionedList:       p.MentionedList,
+			MentionedMobileList: p.MentionedMobiles,
+		}}
+	} else {
+		content := weComMarkdown(msg)
+		var mentions []string
+		for _, id := range p.MentionedList {
+			mentions = append(mentions, "<@"+strings.TrimPrefix(id, "@")+">")
+		}
+		if len(mentions) > 0 {
+			suffix := "\n" + strings.Join(mentions, " ")
+			if room := weComMaxMarkdownBytes - len(suffix); room > 0 {
+				content = truncateBytes(content, room, DefaultTruncateMarker) + suffix
+			} else {
+				// 提及的成員本身已超過上限，只保留上限內的提及
+				content = truncateBytes(strings.TrimPrefix(suffix, "\n"), weComMaxMarkdownBytes, "")
+			}
+		} else {
+			content = truncateBytes(content, weComMaxMarkdownBytes, DefaultTruncateMarker)
+		}
+		payload = weComPayload{MsgType: WeComMarkdown, Markdown: &weComContent{Content: content}}
+	}
+
+	data, err := sonic.Marshal(payload)
+	if err != nil {
+		return nil, NewSerializationError(err)
+	}
+	return data, nil
+}
+
+// CheckResponse 企業微信失敗時仍返回 HTTP 200，依 errcode 判斷，45009 視為可重試的限流錯誤
+func (*WeComProvider) CheckResponse(webhookURL string, statusCode int, body []byte) error {
+	return checkErrcodeResponse(webhookURL, statusCode, body, weComRateLimitCode)
+}
+
+// weComMarkdown 將訊息轉換為企業微信 markdown（僅支援 info、comment、warning 三種顏色）
+func weComMarkdown(msg Message) string {
+	var parts []string
+	if msg.Text != "" {
+		parts = append(parts, format.ToMarkdown(msg.Text))
+	}
+	for _, a := range msg.Attachments {
+		var lines []string
+		if a.Pretext != "" {
+			lines = append(lines, format.ToMarkdown(a.Pretext))
+		}
+		if a.Title != "" {
+			title := markdownLink(a.Title, a.TitleLink)
+			if color := weComColor(a.Color); color != "" {
+				title = fmt.Sprintf(`<font color="%s">%s</font>`, color, title)
+			}
+			lines = append(lines, "### "+title)
+		}
+		if a.AuthorName != "" {
+			lines = append(lines, `<font color="comment">`+a.AuthorName+`</font>`)
+		}
+		if a.Text != "" {
+			lines = append(lines, format.ToMarkdown(a.Text))
+		}
+		for _, f := range a.Fields {
+			lines = append(lines, fmt.Sprintf("> %s: %s", f.Title, format.ToMarkdown(f.Value)))
+		}
+		if a.ImageURL != "" {
+			lines = append(lines, markdownLink(firstNonEmpty(a.Fallback, a.ImageURL), a.ImageURL))
+		}
+		if a.Footer != "" {
+			lines = append(lines, `<font color="comment">`+a.Footer+`</font>`)
+		}
+		if len(lines) == 0 {
+			lines = append(lines, a.Fallback)
+		}
+		parts = append(parts, strings.Join(lines, "\n"))
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// weComColor 將 attachment 顏色對應為企業微信的顏色名稱
+func weComColor(color string) string {
+	switch strings.ToLower(color) {
+	case "":
+		return ""
+	case "good", strings.ToLower(Good):
+		return "info"
+	case "warning", strings.ToLower(Warning), "danger", strings.ToLower(Danger):
+		return "warning"
+	default:
+		return "comment"
+	}
+}
+
+// truncateBytes 將字串截斷到 limit 位元組以內（不切斷 UTF-8 字元），含 marker
+func truncateBytes(s string, limit int, marker string) string {
+	if limit <= 0 || len(s) <= limit {
+		return s
+	}
+	cut := limit - len(marker)
+	if cut < 0 {
+		cut, marker = limit, ""
+	}
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + marker
+}
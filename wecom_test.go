@@ -0,0 +1,140 @@
+package samhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/bytedance/sonic"
+)
+
+func TestWeComProvider_Encode(t *testing.T) {
+	msg := Message{
+		Text: "部署完成",
+		Attachments: []Attachment{{
+			Color:  Good,
+			Title:  "api",
+			Fields: []Field{{Title: "Region", Value: "cn-north-1"}},
+		}},
+	}
+
+	data, err := (&WeComProvider{MentionedList: []string{"zhangsan"}}).Encode("", msg)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	var payload weComPayload
+	if err := sonic.Unmarshal(data, &payload); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if payload.MsgType != WeComMarkdown || payload.Markdown == nil {
+		t.Fatalf("unexpected payload: %s", data)
+	}
+	for _, want := range []string{"部署完成", `<font color="info">api</font>`, "> Region: cn-north-1", "<@zhangsan>"} {
+		if !strings.Contains(payload.Markdown.Content, want) {
+			t.Errorf("markdown missing %q:\n%s", want, payload.Markdown.Content)
+		}
+	}
+
+	data, _ = (&WeComProvider{MsgType: WeComText, MentionedMobiles: []string{"13800000000"}}).Encode("", msg)
+	sonic.Unmarshal(data, &payload)
+	if payload.MsgType != WeComText || payload.Text.MentionedMobileList[0] != "13800000000" {
+		t.Errorf("unexpected text payload: %s", data)
+	}
+}
+
+func TestWeComProvider_ByteLimit(t *testing.T) {
+	// 中文字元每個 3 位元組，以字元計算不會超過限制
+	msg := Message{Text: strings.Repeat("告警", 1500)}
+	data, err := (&WeComProvider{MentionedList: []string{"@all"}}).Encode("", msg)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	var payload weComPayload
+	sonic.Unmarshal(data, &payload)
+	content := payload.Markdown.Content
+	if len(content) > weComMaxMarkdownBytes || !utf8.ValidString(content) {
+		t.Errorf("content is %d bytes (valid UTF-8: %v)", len(content), utf8.ValidString(content))
+	}
+	if !strings.HasSuffix(content, "<@all>") {
+		t.Error("expected mentions to be kept after truncation")
+	}
+}
+
+func TestWeComProvider_RateLimitRetry(t *testing.T) {
+	attempts := 0
+	server := mockWebhookServer(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Write([]byte(`{"errcode":45009,"errmsg":"api freq out of limit"}`))
+			return
+		}
+		w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+	})
+
+	dest := Destination{
+		URL:      server.URL,
+		Provider: &WeComProvider{},
+		Retry:    &RetryOptions{MaxRetries: 2},
+	}
+	if err := dest.Send(context.Background(), createTestMessage()); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("expected 2 attempts, got %d", attempts)
+	}
+
+	err := checkErrcodeResponse("u", http.StatusOK, []byte(`{"errcode":93000,"errmsg":"invalid webhook url"}`), weComRateLimitCode)
+	var webhookErr *WebhookError
+	if !errors.As(err, &webhookErr) || isRetryable(webhookErr) {
+		t.Errorf("expected non-retryable error, got %v", err)
+	}
+}
+
+func TestWeComProvider_LimitsInBytes(t *testing.T) {
+	// 3000 個中文字元為 9000 位元組，超過 4096 位元組的限制
+	msg := Message{Text: strings.Repeat("告", 3000)}
+
+	if _, err := ApplyLimits(msg, LimitOptions{Provider: &WeComProvider{}}); err == nil {
+		t.Error("expected LimitReject to catch multibyte text over the byte limit")
+	}
+
+	parts, err := ApplyLimits(msg, LimitOptions{Provider: &WeComProvider{}, Strategy: LimitSplit})
+	if err != nil {
+		t.Fatalf("ApplyLimits() error = %v", err)
+	}
+	var joined strings.Builder
+	for i, p := range parts {
+		if len(p.Text) > weComMaxMarkdownBytes || !utf8.ValidString(p.Text) {
+			t.Errorf("part %d is %d bytes (valid UTF-8: %v)", i, len(p.Text), utf8.ValidString(p.Text))
+		}
+		joined.WriteString(p.Text)
+	}
+	if joined.String() != msg.Text {
+		t.Error("split parts do not reassemble to the original text")
+	}
+
+	// text 類型的上限為 2048 位元組
+	if limit := (&WeComProvider{MsgType: WeComText}).Limits().MaxTextLength; limit != weComMaxTextBytes {
+		t.Errorf("text limit = %d, want %d", limit, weComMaxTextBytes)
+	}
+}
+
+func TestWeComProvider_LongMentions(t *testing.T) {
+	// 提及的成員本身超過上限時仍不可超出
+	mentions := make([]string, 500)
+	for i := range mentions {
+		mentions[i] = "user" + strings.Repeat("x", 10)
+	}
+	data, err := (&WeComProvider{MentionedList: mentions}).Encode("", Message{Text: "hello"})
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	var payload weComPayload
+	sonic.Unmarshal(data, &payload)
+	if n := len(payload.Markdown.Content); n > weComMaxMarkdownBytes {
+		t.Errorf("content is %d bytes", n)
+	}
+}
@@ -0,0 +1,80 @@
+package samhook
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/bytedance/sonic"
+)
+
+// Zulip 的錯誤代碼
+const zulipRateLimitCode = "RATE_LIMIT_HIT"
+
+// ZulipProvider Zulip 的 Slack 相容 incoming webhook（/api/v1/external/slack_incoming）
+//
+// 訊息以 Slack 格式發送，目標 stream 與 topic 以查詢參數指定；URL 中已有的
+// 參數優先於此處的設定。
+type ZulipProvider struct {
+	// Stream 目標 stream（頻道），為空時發送私訊給 bot 的擁有者
+	Stream string
+
+	// Topic 目標 topic，為空時使用 Zulip 的預設 topic
+	Topic string
+}
+
+// Name 返回平台名稱
+func (ZulipProvider) Name() string { return ProviderNameZulip }
+
+// Limits 返回 Zulip 的訊息限制（attachment 會轉換為訊息內容，因此共用文字上限）
+func (ZulipProvider) Limits() Limits {
+	return Limits{
+		MaxTextLength:       10000,
+		MaxAttachments:      100,
+		MaxFields:           100,
+		MaxAttachmentText:   10000,
+		MaxTitleLength:      2000,
+		MaxFieldTitleLength: 2000,
+		MaxFieldValueLength: 10000,
+	}
+}
+
+// zulipResponse Zulip API 的回應格式
+type zulipResponse struct {
+	Result     string  `json:"result"`
+	Msg        string  `json:"msg"`
+	Code       string  `json:"code"`
+	RetryAfter float64 `json:"retry-after"`
+}
+
+// ModifyRequest 加入 stream 與 topic 查詢參數
+func (p ZulipProvider) ModifyRequest(req *http.Request) error {
+	query := req.URL.Query()
+	if p.Stream != "" && query.Get("stream") == "" {
+		query.Set("stream", p.Stream)
+	}
+	if p.Topic != "" && query.Get("topic") == "" {
+		query.Set("topic", p.Topic)
+	}
+	req.URL.RawQuery = query.Encode()
+	return nil
+}
+
+// CheckResponse 解析 {"result":"error",...} 回應，RATE_LIMIT_HIT 時讀取 retry-after
+func (ZulipProvider) CheckResponse(webhookURL string, statusCode int, body []byte) error {
+	var resp zulipResponse
+	if err := sonic.Unmarshal(body, &resp); err != nil || resp.Result == "" {
+		if statusCode == http.StatusOK {
+			return nil
+		}
+		return NewAPIError(webhookURL, statusCode, string(body))
+	}
+	if resp.Result == "success" && statusCode == http.StatusOK {
+		return nil
+	}
+	apiErr := NewProviderError(webhookURL, statusCode, string(body), resp.Code, resp.Msg)
+	if resp.Code == zulipRateLimitCode {
+		apiErr.ErrorCode = ErrorCodeAPIRateLimit
+		apiErr.RetryAfter = time.Duration(resp.RetryAfter * float64(time.Second))
+	}
+	return apiErr
+}
@@ -0,0 +1,53 @@
+package samhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestZulipProvider_QueryParams(t *testing.T) {
+	var got []string
+	server := mockWebhookServer(t, func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.URL.Query().Get("stream")+"/"+r.URL.Query().Get("topic"))
+		w.Write([]byte(`{"result":"success","msg":""}`))
+	})
+
+	tests := []struct {
+		name     string
+		url      string
+		provider ZulipProvider
+		want     string
+	}{
+		{name: "provider 設定", url: server.URL + "?api_key=k", provider: ZulipProvider{Stream: "ops", Topic: "deploy"}, want: "ops/deploy"},
+		{name: "URL 參數優先", url: server.URL + "?api_key=k&stream=alerts", provider: ZulipProvider{Stream: "ops", Topic: "deploy"}, want: "alerts/deploy"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got = nil
+			if err := SendWithProvider(context.Background(), tt.provider, tt.url, createTestMessage()); err != nil {
+				t.Fatalf("Send() error = %v", err)
+			}
+			if len(got) != 1 || got[0] != tt.want {
+				t.Errorf("stream/topic = %v, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestZulipProvider_CheckResponse(t *testing.T) {
+	p := ZulipProvider{}
+
+	err := p.CheckResponse("u", http.StatusTooManyRequests, []byte(`{"result":"error","msg":"API usage exceeded rate limit","code":"RATE_LIMIT_HIT","retry-after":0.5}`))
+	var webhookErr *WebhookError
+	if !errors.As(err, &webhookErr) || !isRetryable(webhookErr) || webhookErr.RetryAfter != 500*time.Millisecond {
+		t.Fatalf("unexpected rate limit error: %+v", err)
+	}
+
+	err = p.CheckResponse("u", http.StatusBadRequest, []byte(`{"result":"error","msg":"Stream 'x' does not exist","code":"STREAM_DOES_NOT_EXIST"}`))
+	if !errors.As(err, &webhookErr) || webhookErr.ProviderCode != "STREAM_DOES_NOT_EXIST" || isRetryable(webhookErr) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}